@@ -0,0 +1,92 @@
+package assert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ArtifactsDir returns an EqualOption that writes got and want values,
+// in Go syntax and as JSON, and the full diff to files in dir when
+// the assertion fails. Files are named after the test and the call site,
+// and their paths are printed in the failure message.
+//
+// Artifacts can be enabled for all assertions with
+// the ASSERT_ARTIFACTS_DIR environment variable.
+func ArtifactsDir(dir string) EqualOption {
+	return func(o *equaler) {
+		o.artifactsDir = dir
+	}
+}
+
+// artifacts returns the directory of artifacts or an empty string if disabled.
+func (o *equaler) artifacts() string {
+	if o.artifactsDir != "" {
+		return o.artifactsDir
+	}
+	return os.Getenv("ASSERT_ARTIFACTS_DIR")
+}
+
+// unsafeFileChars are characters replaced in names of artifact files.
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// artifactsNote writes artifacts of a failed comparison of got and want
+// and returns a note with paths of the written files.
+func artifactsNote(t testing.TB, eq *equaler, dir string, got, want any, diff string) string {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Sprintf("\nartifacts not written: %v", err)
+	}
+
+	// values compared without a test are named after the package
+	name := "assert"
+	if t != nil {
+		name = t.Name()
+	}
+	if _, file, line, ok := callSite(); ok {
+		name += "-" + filepath.Base(file) + "-" + strconv.Itoa(line)
+	}
+	prefix := filepath.Join(dir, unsafeFileChars.ReplaceAllString(name, "_"))
+
+	gotText, gotRedacted := eq.sprint(got, nil, nil)
+	wantText, wantRedacted := eq.sprint(want, nil, nil)
+	marshal := func(v any) ([]byte, error) {
+		// JSON cannot mask redacted values
+		if gotRedacted || wantRedacted {
+			return nil, errors.New("skipped, values have redacted fields")
+		}
+		return json.MarshalIndent(v, "", "  ")
+	}
+
+	files := []struct {
+		suffix string
+		data   func() ([]byte, error)
+	}{
+		{".got.go.txt", func() ([]byte, error) { return []byte(gotText + "\n"), nil }},
+		{".want.go.txt", func() ([]byte, error) { return []byte(wantText + "\n"), nil }},
+		{".got.json", func() ([]byte, error) { return marshal(got) }},
+		{".want.json", func() ([]byte, error) { return marshal(want) }},
+		{".diff.txt", func() ([]byte, error) { return []byte(diff), nil }},
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\nartifacts:")
+	for _, f := range files {
+		path := prefix + f.suffix
+		data, err := f.data()
+		if err == nil {
+			err = os.WriteFile(path, data, 0o644)
+		}
+		if err != nil {
+			fmt.Fprintf(&sb, "\n  %s: %v", path, err)
+			continue
+		}
+		fmt.Fprintf(&sb, "\n  %s", path)
+	}
+	return sb.String()
+}
@@ -0,0 +1,60 @@
+package assert
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEqualArtifacts(t *testing.T) {
+	dir := t.TempDir()
+
+	type User struct {
+		Name string
+		Tags []string
+	}
+	got := User{Name: "alice", Tags: []string{"a"}}
+	want := User{Name: "bob", Tags: []string{"a"}}
+
+	atb := &assertTB{TB: t}
+	Equal(atb, got, want, ArtifactsDir(dir))
+	atb.fail(t, "\nartifacts:\n  "+filepath.Join(dir, "TestEqualArtifacts-artifacts_test.go-"))
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*"))
+	NoError(t, err)
+	Len(t, paths, 5)
+	for _, path := range paths {
+		True(t, strings.Contains(atb.message, path))
+	}
+
+	read := func(suffix string) string {
+		t.Helper()
+		for _, path := range paths {
+			if strings.HasSuffix(path, suffix) {
+				return string(Must(os.ReadFile(path)))
+			}
+		}
+		t.Fatalf("missing %s artifact", suffix)
+		return ""
+	}
+	True(t, strings.Contains(read(".got.go.txt"), `Name: "alice"`))
+	True(t, strings.Contains(read(".want.go.txt"), `Name: "bob"`))
+	Equal(t, read(".got.json"), "{\n  \"Name\": \"alice\",\n  \"Tags\": [\n    \"a\"\n  ]\n}")
+	True(t, strings.Contains(read(".want.json"), `"bob"`))
+	True(t, strings.Contains(read(".diff.txt"), `"alice"`))
+}
+
+func TestEqualArtifactsEnv(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "nested")
+	t.Setenv("ASSERT_ARTIFACTS_DIR", dir)
+
+	t.Run("sub/case", func(t *testing.T) {
+		atb := &assertTB{TB: t}
+		Equal(atb, map[string]any{"ch": make(chan int)}, nil)
+		atb.fail(t, filepath.Join(dir, "TestEqualArtifactsEnv_sub_case-artifacts_test.go-"))
+
+		// values that cannot be encoded as JSON are reported
+		atb.fail(t, ".got.json: json: unsupported type: chan int")
+	})
+}
@@ -1,14 +1,15 @@
 package assert
 
 import (
-	"errors"
 	"fmt"
 	"reflect"
-	"regexp"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
 // EqualOption configures the equality check behavior.
@@ -43,53 +44,80 @@ func SkipZeroFields() EqualOption {
 // a specific sub-field that is embedded or nested within the parent struct.
 //
 // This option can be only used for structs, otherwise it will panic.
+// See [JSONEq] and [XMLEq] for the syntax of names used when comparing documents.
 func SkipFieldNames(names ...string) EqualOption {
 	return func(o *equaler) {
 		o.skipFieldNames = append(o.skipFieldNames, names...)
 	}
 }
 
-// Equal checks if two values are equal with the given options.
+// Tolerance returns an EqualOption that treats floating point numbers
+// as equal if their absolute difference is at most margin.
 //
-// This functions uses [go-cmp](https://pkg.go.dev/github.com/google/go-cmp) to determine equality.
-func Equal[V any](t testing.TB, got V, want V, opts ...EqualOption) {
-	if _, ok := any(got).(error); ok {
-		panic("use assert.Error() for errors")
+// It also applies to numbers in [JSONEq] and numeric cells in [CSVEq].
+func Tolerance(margin float64) EqualOption {
+	if margin < 0 {
+		panic("tolerance margin must be non-negative")
 	}
+	return func(o *equaler) {
+		o.tolerance = margin
+	}
+}
 
-	t.Helper()
-	if !equal(got, want, opts...) {
-		t.Fatalf("expected equal\n%s", diffValue(got, want, opts...))
+// EquateEmpty returns an EqualOption that treats nil
+// and empty slices and maps as equal.
+func EquateEmpty() EqualOption {
+	return func(o *equaler) {
+		o.equateEmpty = true
 	}
 }
 
-// NotEqual checks if two values are not equal.
-// See [Equal] for rules used to determine equality.
-func NotEqual[V any](t testing.TB, got V, want V, opts ...EqualOption) {
-	if _, ok := any(got).(error); ok {
-		panic("use assert.Error() for errors")
+// EquateApproxTime returns an EqualOption that treats [time.Time]
+// values as equal if they are within the margin of each other.
+func EquateApproxTime(margin time.Duration) EqualOption {
+	if margin < 0 {
+		panic("time margin must be non-negative")
 	}
+	return func(o *equaler) {
+		o.timeMargin = margin
+	}
+}
 
-	t.Helper()
-	if equal(got, want, opts...) {
-		t.Fatalf("expected not equal, but got equal")
+// SortSlices returns an EqualOption that sorts slices of type []T
+// with less before comparing them, so their order does not matter.
+func SortSlices[T any](less func(a, b T) bool) EqualOption {
+	return func(o *equaler) {
+		o.sortSlices = append(o.sortSlices, cmpopts.SortSlices(less))
 	}
 }
 
+// Equal checks if two values are equal with the given options.
+//
+// This functions uses [go-cmp](https://pkg.go.dev/github.com/google/go-cmp) to determine equality.
+func Equal[V any](t testing.TB, got V, want V, opts ...EqualOption) bool {
+	t.Helper()
+	explain(t, got, want, opts...)
+	return failed(t, 1, checkEqual(t, got, want, opts...))
+}
+
+// NotEqual checks if two values are not equal.
+// See [Equal] for rules used to determine equality.
+func NotEqual[V any](t testing.TB, got V, want V, opts ...EqualOption) bool {
+	t.Helper()
+	explain(t, got, want, opts...)
+	return failed(t, 1, CheckNotEqual(got, want, opts...))
+}
+
 // Error checks if an error is not nil.
-func Error(t testing.TB, err error) {
+func Error(t testing.TB, err error) bool {
 	t.Helper()
-	if err == nil {
-		t.Fatalf("expected error, got nil")
-	}
+	return failed(t, 1, CheckError(err))
 }
 
 // NoError checks if an error is nil.
-func NoError(t testing.TB, err error) {
+func NoError(t testing.TB, err error) bool {
 	t.Helper()
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
+	return failed(t, 1, CheckNoError(err))
 }
 
 // ErrorContains checks if an error is not nil and contains the target.
@@ -108,46 +136,9 @@ func NoError(t testing.TB, err error) {
 // 3. type
 //
 // The error is checked if it can be converted to the target type using errors.As.
-func ErrorContains(t testing.TB, err error, target any) {
+func ErrorContains(t testing.TB, err error, target any) bool {
 	t.Helper()
-	if err == nil {
-		t.Fatalf("error is nil")
-		return
-	}
-
-	// catch any errors.Is/As panics
-	defer func() {
-		if r := recover(); r != nil {
-			t.Fatalf("error.Is/As panic %s", r)
-		}
-	}()
-
-	switch e := target.(type) {
-	case string:
-		// if this is a valid regexp, compile it and use it
-		// otherwise, just use it as a string
-
-		// first check the string itself
-		if !strings.Contains(err.Error(), e) {
-			if re, err1 := regexp.Compile(e); err1 == nil {
-				if !re.MatchString(err.Error()) {
-					t.Fatalf("unexpected error: %q does not match %q", err, e)
-				}
-			} else {
-				t.Fatalf("unexpected error: %q does not contain %q", err, e)
-			}
-		}
-
-	case error:
-		if !errors.Is(err, e) {
-			t.Fatalf("unexpected error: %q is not %T", err, e)
-		}
-
-	default:
-		if !errors.As(err, e) {
-			t.Fatalf("unexpected error: %q is not %T", err, e)
-		}
-	}
+	return failed(t, 1, CheckErrorContains(err, target))
 }
 
 // ErrorWant checks if an error is expected for the test.
@@ -165,134 +156,90 @@ func ErrorContains(t testing.TB, err error, target any) {
 //			assert.ErrorWant(t, tt.wantErr, err)
 //		})
 //	}
-func ErrorWant(t testing.TB, want bool, err error) {
+func ErrorWant(t testing.TB, want bool, err error) bool {
 	t.Helper()
-	if want && err == nil {
-		t.Fatalf("expected error: got nil")
-	} else if !want && err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
+	return failed(t, 2, CheckErrorWant(want, err))
 }
 
 // Zero checks if got is zero value.
 // If value implements IsZero() bool method,
 // it will be used to determine if the value is zero.
-func Zero[T any](t testing.TB, got T) {
+func Zero[T any](t testing.TB, got T) bool {
 	t.Helper()
-	if !isZero(got) {
-		t.Fatalf("expected zero, got %v", got)
-	}
+	return failed(t, 1, CheckZero(got))
 }
 
 // NotZero checks if got is not zero value.
 // If value implements IsZero() bool method,
 // it will be used to determine if the value is zero.
-func NotZero[T any](t testing.TB, got T) {
+func NotZero[T any](t testing.TB, got T) bool {
 	t.Helper()
-	if isZero(got) {
-		t.Fatalf("expected not zero, got %v", got)
-	}
+	return failed(t, 1, CheckNotZero(got))
 }
 
 // Empty checks if got is empty.
-func Empty(t testing.TB, got any) {
-	if _, ok := got.(error); ok {
-		panic("use assert.NoError() for errors")
-	}
-
+func Empty(t testing.TB, got any) bool {
 	t.Helper()
-	if !isEmpty(got) {
-		t.Fatalf("expected empty, got %v", got)
-	}
+	return failed(t, 1, CheckEmpty(got))
 }
 
 // NotEmpty checks if got is not empty.
-func NotEmpty(t testing.TB, got any) {
-	if _, ok := got.(error); ok {
-		panic("use assert.Error() for errors")
-	}
-
+func NotEmpty(t testing.TB, got any) bool {
 	t.Helper()
-	if isEmpty(got) {
-		t.Fatalf("expected not empty, got empty")
-	}
+	return failed(t, 1, CheckNotEmpty(got))
 }
 
 // Nil checks if got is nil.
-func Nil(t testing.TB, got any) {
-	if _, ok := got.(error); ok {
-		panic("use assert.NoError() for errors")
-	}
-
+func Nil(t testing.TB, got any) bool {
 	t.Helper()
-	if !isNil(got) {
-		t.Fatalf("expected nil, got %v", got)
-	}
+	return failed(t, 1, CheckNil(got))
 }
 
 // NotNil checks if got is not nil.
-func NotNil(t testing.TB, got any) {
-	if _, ok := got.(error); ok {
-		panic("use assert.Error() for errors")
-	}
-
+func NotNil(t testing.TB, got any) bool {
 	t.Helper()
-	if isNil(got) {
-		t.Fatalf("expected not nil, got nil")
-	}
+	return failed(t, 1, CheckNotNil(got))
 }
 
 // Len checks if the length of got is l.
 // got can be any go type accepted by builtin len function.
-func Len[V any](t testing.TB, got V, want int) {
+func Len[V any](t testing.TB, got V, want int) bool {
 	t.Helper()
+	return failed(t, 1, CheckLen(got, want))
+}
 
-	l := reflect.ValueOf(got).Len()
-	if l != want {
-		t.Fatalf("expected length %d, got %d", want, l)
-	}
+// Contains checks if got contains elem.
+// got can be a string, in which case elem must be a substring,
+// a slice or an array, in which case elem must be equal to one
+// of the elements, or a map, in which case elem must be a key.
+// See [Equal] for rules used to compare elements.
+func Contains(t testing.TB, got any, elem any, opts ...EqualOption) bool {
+	t.Helper()
+	return failed(t, 1, CheckContains(got, elem, opts...))
 }
 
 // True checks if got is true.
-func True(t testing.TB, got bool) {
+func True(t testing.TB, got bool) bool {
 	t.Helper()
-	if !got {
-		t.Fatalf("expected true, got false")
-	}
+	return failed(t, 1, CheckTrue(got))
 }
 
 // False checks if got is false.
-func False(t testing.TB, got bool) {
+func False(t testing.TB, got bool) bool {
 	t.Helper()
-	if got {
-		t.Fatalf("expected false, got true")
-	}
+	return failed(t, 1, CheckFalse(got))
 }
 
 // Panic checks if f panics.
-func Panic(t testing.TB, f func()) {
+func Panic(t testing.TB, f func()) bool {
 	t.Helper()
-
-	defer func() {
-		t.Helper()
-		if r := recover(); r == nil {
-			t.Fatalf("expected panic, got nothing")
-		}
-	}()
-	f()
+	return failed(t, 1, CheckPanic(f))
 }
 
 // NotPanic checks if f does not panic.
-func NotPanic(t testing.TB, f func()) {
+func NotPanic(t testing.TB, f func()) bool {
 	t.Helper()
-
-	defer func() {
-		t.Helper()
-		if r := recover(); r != nil {
-			t.Fatalf("unexpected panic: %v", r)
-		}
-	}()
-	f()
+	return failed(t, 1, CheckNotPanic(f))
 }
 
 // Defer returns a function that will call fn and check if an error is returned.
@@ -308,10 +255,8 @@ func Defer(t testing.TB, fn func() error) func() {
 // TypeAssert checks if got is of type V and returns it.
 func TypeAssert[V any](t testing.TB, got any) V {
 	t.Helper()
-	v, ok := got.(V)
-	if !ok {
-		t.Fatalf("assertion %T.(%T) failed", got, v)
-	}
+	v, err := CheckTypeAssert[V](got)
+	failed(t, 1, err)
 	return v
 }
 
@@ -352,46 +297,151 @@ type equaler struct {
 	// skipFieldNames is a list of field names to
 	// skip in the equality check.
 	skipFieldNames []string
-}
 
-func newEqualer() *equaler {
-	return &equaler{}
+	// tolerance is the maximum absolute difference
+	// between numbers considered equal.
+	tolerance float64
+
+	// diffStyle is the style of diffs, unified or side-by-side.
+	diffStyle string
+
+	// diffWidth is the width of side-by-side diffs.
+	diffWidth int
+
+	// contextLines is the number of context lines in unified diffs of texts.
+	contextLines *int
+
+	// hexContext is the number of bytes shown around mismatches in hexdump diffs.
+	hexContext *int
+
+	// artifactsDir is the directory where artifacts of failures are written.
+	artifactsDir string
+
+	// maxDiffLines, maxDiffPaths and maxValueBytes limit the size of diffs.
+	maxDiffLines  int
+	maxDiffPaths  int
+	maxValueBytes int
+
+	// formatters are per-call formatters of values in failure messages.
+	formatters map[reflect.Type]formatter
+
+	// formatStringers enables formatting with String and MarshalText methods.
+	formatStringers bool
+
+	// redactFields are paths of struct fields redacted in failure messages.
+	redactFields []string
+
+	// equateEmpty treats nil and empty slices and maps as equal.
+	equateEmpty bool
+
+	// timeMargin is the margin of approximately equal times.
+	timeMargin time.Duration
+
+	// sortSlices are options sorting slices before comparison.
+	sortSlices []cmp.Option
+
+	// explain logs paths ignored by options.
+	explain bool
+
+	// explainer records paths ignored by options, if set.
+	explainer *explainer
 }
 
-func (o *equaler) apply(typ any, opts ...EqualOption) cmp.Options {
+func newEqualer(opts ...EqualOption) *equaler {
+	o := &equaler{}
 	for _, opt := range opts {
 		opt(o)
 	}
+	return o
+}
 
+// cmpOptions converts the equaler configuration into [cmp.Options]
+// for values of the same type as typ.
+func (o *equaler) cmpOptions(typ any) cmp.Options {
 	out := []cmp.Option{}
 	if o.ignoreUnexported {
-		out = append(out, ignoreUnexported())
+		out = append(out, ignoreUnexported(o.explainer))
 	} else {
 		out = append(out, compareExported())
 	}
 
 	if o.skipEmptyFields {
-		out = append(out, ignoreEmptyFields())
+		out = append(out, ignoreEmptyFields(o.explainer))
 	}
 
 	if o.skipZeroFields {
-		out = append(out, ignoreZeroFields())
+		out = append(out, ignoreZeroFields(o.explainer))
 	}
 
 	if len(o.skipFieldNames) > 0 {
-		out = append(out, ignoreFieldNames(typ, o.skipFieldNames...))
+		out = append(out, ignoreFieldNames(o.explainer, typ, o.skipFieldNames...))
+	}
+
+	if o.tolerance > 0 {
+		out = append(out, cmpopts.EquateApprox(0, o.tolerance))
+	}
+
+	if o.equateEmpty {
+		out = append(out, cmpopts.EquateEmpty())
 	}
 
+	if o.timeMargin > 0 {
+		out = append(out, cmpopts.EquateApproxTime(o.timeMargin))
+	}
+
+	out = append(out, o.sortSlices...)
+
 	return out
 }
 
 func equal[V any](got V, want V, opts ...EqualOption) bool {
-	eq := newEqualer()
+	eq := newEqualer(opts...)
 	var zero V
-	cmpOpts := eq.apply(zero, opts...)
+	cmpOpts := eq.cmpOptions(zero)
 	return cmp.Equal(got, want, cmpOpts...)
 }
 
+// contains reports whether got contains elem, see [Contains].
+func contains(got any, elem any, opts ...EqualOption) bool {
+	rv := reflect.ValueOf(got)
+	switch rv.Kind() {
+	case reflect.String:
+		s, ok := elem.(string)
+		if !ok {
+			panic(fmt.Sprintf("Contains: cannot look for %T in %T", elem, got))
+		}
+		return strings.Contains(rv.String(), s)
+
+	case reflect.Slice, reflect.Array, reflect.Map:
+		typ := rv.Type().Elem()
+		if rv.Kind() == reflect.Map {
+			typ = rv.Type().Key()
+		}
+		ev := reflect.ValueOf(elem)
+		if !ev.IsValid() {
+			ev = reflect.Zero(typ)
+		}
+		if !ev.Type().AssignableTo(typ) {
+			panic(fmt.Sprintf("Contains: cannot look for %T in %T", elem, got))
+		}
+
+		if rv.Kind() == reflect.Map {
+			return rv.MapIndex(ev).IsValid()
+		}
+		eq := newEqualer(opts...)
+		for i := range rv.Len() {
+			v := rv.Index(i).Interface()
+			if cmp.Equal(v, ev.Interface(), eq.cmpOptions(v)...) {
+				return true
+			}
+		}
+		return false
+
+	default:
+		panic(fmt.Sprintf("Contains: unsupported type %T, use string, slice, array or map", got))
+	}
+}
+
 func isNil(obj any) bool {
 	if obj == nil {
 		return true
@@ -407,20 +457,176 @@ func isNil(obj any) bool {
 	return false
 }
 
-func diffValue[V any](a V, b V, opts ...EqualOption) string {
+// diffValue returns the diff of a and b. If the diff is truncated or
+// artifacts are enabled, full values and diffs are written to files.
+func diffValue[V any](t testing.TB, a V, b V, opts ...EqualOption) string {
+	eq := newEqualer(opts...)
+	color := colorEnabled()
+
+	var zero V
+	cmpOpts := eq.cmpOptions(zero)
+	r := collectDiffs(a, b, eq, cmpOpts)
+
+	limits := eq.limits()
+	shown := r.diffs
+	if limits.paths > 0 && len(shown) > limits.paths {
+		shown = shown[:limits.paths]
+	}
+
+	out, limited, cut := eq.renderDiff(a, b, cmpOpts, r, shown, limits, color)
+	out, linesCut := limitDiff(out, limits)
+	truncated := linesCut || cut
+	if limited || truncated {
+		out = diffSummary(r.diffs, len(shown), limited, limits.lines, linesCut) + "\n" + out
+	}
+
+	if dir := eq.artifacts(); dir != "" {
+		full, _, _ := eq.renderDiff(a, b, cmpOpts, r, r.diffs, diffLimits{}, false)
+		return out + artifactsNote(t, eq, dir, a, b, full)
+	}
+	if limited || truncated {
+		full, _, _ := eq.renderDiff(a, b, cmpOpts, r, r.diffs, diffLimits{}, false)
+		return out + fullDiffNote(full)
+	}
+	return out
+}
+
+// renderDiff returns the diff of a and b showing only the given differences.
+// Multi-line texts and binary data are cut to the limits before they are
+// diffed, and lines of other diffs are cut to the limit of value bytes.
+// limited reports whether other differences were left out,
+// and cut whether any value was cut.
+func (o *equaler) renderDiff(a, b any, cmpOpts cmp.Options, r *diffReporter, shown []difference, limits diffLimits, color bool) (out string, limited, cut bool) {
+	// values with redacted fields are shown only in their masked Go syntax,
+	// which is also laid out in side-by-side diffs; cmp does not report
+	// values within missing map entries or nil pointers, so types are checked
+	redacted := r.redacted || o.hasRedacted(reflect.TypeOf(a)) || o.hasRedacted(reflect.TypeOf(b))
+	twoColumns := o.style() == diffSideBySide && !o.relaxed()
+
+	// differences left out by the limit of paths are written as
+	// "<not shown>" in Go syntax, as cmp reports ignored values
+	// the same way as values ignored by options
+	keys := make(map[string]bool, len(shown))
+	for _, d := range shown {
+		keys[d.key] = true
+	}
+	hidden := map[string]bool{}
+	for _, d := range r.diffs {
+		if !keys[d.key] {
+			hidden[d.name] = true
+		}
+	}
+
+	var gotText, wantText string
+	if redacted || twoColumns || len(hidden) > 0 {
+		differs := r.diffNames()
+		gotText, _ = o.sprint(a, differs, hidden)
+		wantText, _ = o.sprint(b, differs, hidden)
+	}
+
+	cutLines := func(s string) string {
+		s, c := truncateLines(s, limits.valueBytes, color)
+		cut = cut || c
+		return s
+	}
+
 	// first let GoStringer format the values if they implement it
-	out := ""
-	if _, ok := any(a).(fmt.GoStringer); ok {
-		out += diffGoStringer(any(a).(fmt.GoStringer), any(b).(fmt.GoStringer))
+	if _, ok := a.(fmt.GoStringer); ok && !redacted {
+		out += cutLines(diffGoStringer(a.(fmt.GoStringer), b.(fmt.GoStringer)))
 		out += "\n"
 	}
 
-	eq := newEqualer()
-	var zero V
-	cmpOpts := eq.apply(zero, opts...)
+	if twoColumns {
+		if diff, ok := sideBySide(gotText, wantText, o.width(), color); ok {
+			out += "diff:\n"
+			out += diff
+			return out, len(hidden) > 0, cut
+		}
+	}
+
+	if o.style() == diffPaths {
+		out += "diff:\n"
+		out += cutLines(o.pathList(shown))
+		return out, len(hidden) > 0, cut
+	}
+
+	if redacted || len(hidden) > 0 {
+		// redacted values look the same on both sides, so they are also listed
+		out += "diff:\n"
+		diff := unifiedDiff(gotText, wantText, o.context(), color)
+		for _, d := range shown {
+			if d.redacted {
+				diff += o.pathLine(d) + "\n"
+			}
+		}
+		out += cutLines(diff)
+		return out, len(hidden) > 0, cut
+	}
+
+	// multi-line texts, binary data and values with formatters
+	// are rendered separately as unified diffs, hexdumps and path lists
+	if ignored := slices.Concat(r.texts, r.binaries, r.formatted); len(ignored) > 0 {
+		cmpOpts = append(cmpOpts, ignoreDiffs(ignored))
+	}
+
+	diff := cmp.Diff(a, b, cmpOpts...)
+	if color {
+		diff = colorizeDiff(diff)
+	}
+	diff = cutLines(diff)
+	// texts and binary data are not rendered after the line limit is reached
+	full := func() bool {
+		return limits.lines > 0 && strings.Count(diff, "\n") > limits.lines
+	}
+	for _, d := range r.texts {
+		if !keys[d.key] {
+			continue
+		}
+		if full() {
+			cut = true
+			break
+		}
+		got, want, _ := multilineText(d.got, d.want)
+		gotc, wantc, line, more := limits.cutText(got, want, o.context())
+		cut = cut || len(gotc) != len(got) || len(wantc) != len(want)
+		if d.name != "" {
+			diff += d.name + ":\n"
+		}
+		diff += unifiedDiffAt(gotc, wantc, line, o.context(), color)
+		if more > 0 {
+			diff += fmt.Sprintf("... (%d more bytes)\n", more)
+		}
+	}
+	for _, d := range r.binaries {
+		if !keys[d.key] {
+			continue
+		}
+		if full() {
+			cut = true
+			break
+		}
+		got, want, _ := binaryBytes(d.got, d.want)
+		gotc, wantc, offset, more := limits.cutBytes(got, want)
+		cut = cut || len(gotc) != len(got) || len(wantc) != len(want)
+		if d.name != "" {
+			diff += d.name + ":\n"
+		}
+		if offset > 0 {
+			diff += fmt.Sprintf("... %d equal bytes\n", offset)
+		}
+		diff += hexDiffAt(gotc, wantc, offset, o.hexContextBytes(), color)
+		if more > 0 {
+			diff += fmt.Sprintf("... (%d more bytes)\n", more)
+		}
+	}
+	for _, d := range r.formatted {
+		if keys[d.key] {
+			diff += cutLines(o.pathLine(d) + "\n")
+		}
+	}
 	out += "diff:\n"
-	out += cmp.Diff(a, b, cmpOpts...)
-	return out
+	out += diff
+	return out, false, cut
 }
 
 func diffGoStringer(a, b fmt.GoStringer) string {
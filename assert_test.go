@@ -75,6 +75,13 @@ func TestEqual(t *testing.T) {
 	Equal(atb, bytes.NewReader([]byte("a")), bytes.NewReader(nil))
 	atb.fail(t, "expected equal")
 
+	// self-referencing values
+	a, b := map[string]any{"n": 1}, map[string]any{"n": 2}
+	a["self"], b["self"] = a, b
+	atb = &assertTB{TB: t}
+	Equal(atb, a, b)
+	atb.fail(t, "expected equal")
+
 	Panic(t, func() {
 		Equal(t, fmt.Errorf("0"), fmt.Errorf("0"))
 	})
@@ -388,11 +395,11 @@ func TestZero(t *testing.T) {
 		{value: time.Time{}.In(time.Local), fail: ""},
 		{value: 0, fail: ""},
 		{value: .0, fail: ""},
-		{value: make(chan int), fail: "expected zero, got 0x"},
+		{value: make(chan int), fail: "expected zero, got (chan int)(0x"},
 		{value: map[string]string(nil), fail: ""},
-		{value: make(map[string]string), fail: "expected zero, got map[]"},
+		{value: make(map[string]string), fail: "expected zero, got map[string]string{}"},
 		{value: []int(nil), fail: ""},
-		{value: []int{}, fail: "expected zero, got []"},
+		{value: []int{}, fail: "expected zero, got []int{}"},
 	}
 
 	for _, tt := range tests {
@@ -409,15 +416,15 @@ func TestNotZero(t *testing.T) {
 		value any
 		fail  string
 	}{
-		{value: nil, fail: "expected not zero, got <nil>"},
-		{value: time.Time{}, fail: "expected not zero, got 0001-01-01 00:00:00 +0000 UTC"},
-		{value: time.Time{}.In(time.Local), fail: "expected not zero, got 0001-01"},
+		{value: nil, fail: "expected not zero, got nil"},
+		{value: time.Time{}, fail: "expected not zero, got time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC)"},
+		{value: time.Time{}.In(time.Local), fail: "expected not zero, got time.Date(1, time.January, 1, 0, 0, 0, 0, time.Local)"},
 		{value: 0, fail: "expected not zero, got 0"},
 		{value: .0, fail: "expected not zero, got 0"},
 		{value: make(chan int), fail: ""},
-		{value: map[string]string(nil), fail: "expected not zero, got map[]"},
+		{value: map[string]string(nil), fail: "expected not zero, got (map[string]string)(nil)"},
 		{value: make(map[string]string), fail: ""},
-		{value: []int(nil), fail: "expected not zero, got []"},
+		{value: []int(nil), fail: "expected not zero, got ([]int)(nil)"},
 		{value: []int{}, fail: ""},
 	}
 
@@ -437,7 +444,7 @@ func TestEmpty(t *testing.T) {
 	}{
 		{value: nil, fail: ""},
 		{value: time.Time{}, fail: ""},
-		{value: time.Time{}.In(time.Local), fail: "expected empty, got 0001-01-01"},
+		{value: time.Time{}.In(time.Local), fail: "expected empty, got time.Date(1, time.January, 1, 0, 0, 0, 0, time.Local)"},
 		{value: 0, fail: ""},
 		{value: .0, fail: ""},
 		{value: make(chan int), fail: ""},
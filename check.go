@@ -0,0 +1,285 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// Failure is the error returned by Check functions when the check fails.
+type Failure struct {
+	// Message describes the failure, e.g. "expected equal".
+	Message string
+
+	// Got and Want are the checked and expected values,
+	// Want is nil for checks without an expected value.
+	Got  any
+	Want any
+
+	// Diff is the difference between Got and Want,
+	// empty for checks that do not compare values.
+	Diff string
+
+	// hint suggests options that make the values equal.
+	hint string
+}
+
+func (e *Failure) Error() string {
+	if e.Diff == "" {
+		return e.Message + e.hint
+	}
+	return e.Message + "\n" + e.Diff + e.hint
+}
+
+// failed fails the test with err, if it is not nil, and reports whether err is nil.
+// The message is prefixed by the source expression of the argument at index arg.
+func failed(t testing.TB, arg int, err error) bool {
+	t.Helper()
+	if err != nil {
+		fatalf(t, arg, "%s", err)
+		return false
+	}
+	return true
+}
+
+// CheckEqual returns a [*Failure] if got and want are not equal.
+// It is [Equal] for code without a testing.TB, e.g. fuzz harnesses,
+// examples or TestMain.
+func CheckEqual[V any](got V, want V, opts ...EqualOption) error {
+	return checkEqual(nil, got, want, opts...)
+}
+
+func checkEqual[V any](t testing.TB, got V, want V, opts ...EqualOption) error {
+	if _, ok := any(got).(error); ok {
+		panic("use assert.Error() for errors")
+	}
+
+	if !equal(got, want, opts...) {
+		return &Failure{
+			Message: "expected equal",
+			Got:     got,
+			Want:    want,
+			Diff:    diffValue(t, got, want, opts...),
+			hint:    hints(got, want, opts...),
+		}
+	}
+	return nil
+}
+
+// CheckNotEqual returns a [*Failure] if got and want are equal, see [NotEqual].
+func CheckNotEqual[V any](got V, want V, opts ...EqualOption) error {
+	if _, ok := any(got).(error); ok {
+		panic("use assert.Error() for errors")
+	}
+
+	if equal(got, want, opts...) {
+		return &Failure{Message: "expected not equal, but got equal", Got: got, Want: want}
+	}
+	return nil
+}
+
+// CheckError returns a [*Failure] if err is nil, see [Error].
+func CheckError(err error) error {
+	if err == nil {
+		return &Failure{Message: "expected error, got nil"}
+	}
+	return nil
+}
+
+// CheckNoError returns a [*Failure] if err is not nil, see [NoError].
+func CheckNoError(err error) error {
+	if err != nil {
+		return &Failure{Message: fmt.Sprintf("unexpected error: %v", err), Got: err}
+	}
+	return nil
+}
+
+// CheckErrorContains returns a [*Failure] if err is nil or does not
+// contain the target, see [ErrorContains].
+func CheckErrorContains(err error, target any) (cerr error) {
+	if err == nil {
+		return &Failure{Message: "error is nil", Want: target}
+	}
+
+	// catch any errors.Is/As panics
+	defer func() {
+		if r := recover(); r != nil {
+			cerr = &Failure{Message: fmt.Sprintf("error.Is/As panic %s", r), Got: err, Want: target}
+		}
+	}()
+
+	switch e := target.(type) {
+	case string:
+		// if this is a valid regexp, compile it and use it
+		// otherwise, just use it as a string
+
+		// first check the string itself
+		if !strings.Contains(err.Error(), e) {
+			if re, err1 := regexp.Compile(e); err1 == nil {
+				if !re.MatchString(err.Error()) {
+					return &Failure{Message: fmt.Sprintf("unexpected error: %q does not match %q", err, e), Got: err, Want: target}
+				}
+			} else {
+				return &Failure{Message: fmt.Sprintf("unexpected error: %q does not contain %q", err, e), Got: err, Want: target}
+			}
+		}
+
+	case error:
+		if !errors.Is(err, e) {
+			return &Failure{Message: fmt.Sprintf("unexpected error: %q is not %T", err, e), Got: err, Want: target}
+		}
+
+	default:
+		if !errors.As(err, e) {
+			return &Failure{Message: fmt.Sprintf("unexpected error: %q is not %T", err, e), Got: err, Want: target}
+		}
+	}
+	return nil
+}
+
+// CheckErrorWant returns a [*Failure] if err is nil and want is true,
+// or if err is not nil and want is false, see [ErrorWant].
+func CheckErrorWant(want bool, err error) error {
+	if want && err == nil {
+		return &Failure{Message: "expected error: got nil", Want: want}
+	} else if !want && err != nil {
+		return &Failure{Message: fmt.Sprintf("unexpected error: %v", err), Got: err, Want: want}
+	}
+	return nil
+}
+
+// CheckZero returns a [*Failure] if got is not zero, see [Zero].
+func CheckZero[T any](got T) error {
+	if !isZero(got) {
+		return &Failure{Message: fmt.Sprintf("expected zero, got %s", Sprint(got)), Got: got}
+	}
+	return nil
+}
+
+// CheckNotZero returns a [*Failure] if got is zero, see [NotZero].
+func CheckNotZero[T any](got T) error {
+	if isZero(got) {
+		return &Failure{Message: fmt.Sprintf("expected not zero, got %s", Sprint(got)), Got: got}
+	}
+	return nil
+}
+
+// CheckEmpty returns a [*Failure] if got is not empty, see [Empty].
+func CheckEmpty(got any) error {
+	if _, ok := got.(error); ok {
+		panic("use assert.NoError() for errors")
+	}
+
+	if !isEmpty(got) {
+		return &Failure{Message: fmt.Sprintf("expected empty, got %s", Sprint(got)), Got: got}
+	}
+	return nil
+}
+
+// CheckNotEmpty returns a [*Failure] if got is empty, see [NotEmpty].
+func CheckNotEmpty(got any) error {
+	if _, ok := got.(error); ok {
+		panic("use assert.Error() for errors")
+	}
+
+	if isEmpty(got) {
+		return &Failure{Message: "expected not empty, got empty", Got: got}
+	}
+	return nil
+}
+
+// CheckNil returns a [*Failure] if got is not nil, see [Nil].
+func CheckNil(got any) error {
+	if _, ok := got.(error); ok {
+		panic("use assert.NoError() for errors")
+	}
+
+	if !isNil(got) {
+		return &Failure{Message: fmt.Sprintf("expected nil, got %s", Sprint(got)), Got: got}
+	}
+	return nil
+}
+
+// CheckNotNil returns a [*Failure] if got is nil, see [NotNil].
+func CheckNotNil(got any) error {
+	if _, ok := got.(error); ok {
+		panic("use assert.Error() for errors")
+	}
+
+	if isNil(got) {
+		return &Failure{Message: "expected not nil, got nil", Got: got}
+	}
+	return nil
+}
+
+// CheckLen returns a [*Failure] if the length of got is not want, see [Len].
+func CheckLen[V any](got V, want int) error {
+	l := reflect.ValueOf(got).Len()
+	if l != want {
+		return &Failure{Message: fmt.Sprintf("expected length %d, got %d", want, l), Got: got, Want: want}
+	}
+	return nil
+}
+
+// CheckContains returns a [*Failure] if got does not contain elem, see [Contains].
+func CheckContains(got any, elem any, opts ...EqualOption) error {
+	if !contains(got, elem, opts...) {
+		eq := newEqualer(opts...)
+		msg := fmt.Sprintf("expected %s to contain %s",
+			eq.sprintLine(reflect.ValueOf(got), "", "", nil),
+			eq.sprintLine(reflect.ValueOf(elem), "", "", nil))
+		return &Failure{Message: msg, Got: got, Want: elem}
+	}
+	return nil
+}
+
+// CheckTrue returns a [*Failure] if got is false, see [True].
+func CheckTrue(got bool) error {
+	if !got {
+		return &Failure{Message: "expected true, got false", Got: got, Want: true}
+	}
+	return nil
+}
+
+// CheckFalse returns a [*Failure] if got is true, see [False].
+func CheckFalse(got bool) error {
+	if got {
+		return &Failure{Message: "expected false, got true", Got: got, Want: false}
+	}
+	return nil
+}
+
+// CheckPanic returns a [*Failure] if f does not panic, see [Panic].
+func CheckPanic(f func()) (err error) {
+	defer func() {
+		if recover() == nil {
+			err = &Failure{Message: "expected panic, got nothing"}
+		}
+	}()
+	f()
+	return nil
+}
+
+// CheckNotPanic returns a [*Failure] if f panics, see [NotPanic].
+func CheckNotPanic(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &Failure{Message: fmt.Sprintf("unexpected panic: %v", r), Got: r}
+		}
+	}()
+	f()
+	return nil
+}
+
+// CheckTypeAssert returns got as type V, or a [*Failure]
+// if got is not of type V, see [TypeAssert].
+func CheckTypeAssert[V any](got any) (V, error) {
+	v, ok := got.(V)
+	if !ok {
+		return v, &Failure{Message: fmt.Sprintf("assertion %T.(%T) failed", got, v), Got: got}
+	}
+	return v, nil
+}
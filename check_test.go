@@ -0,0 +1,110 @@
+package assert
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckEqual(t *testing.T) {
+	type User struct {
+		ID    int
+		Email string
+	}
+
+	NoError(t, CheckEqual(User{ID: 1}, User{ID: 1}))
+
+	got, want := User{ID: 1, Email: "a"}, User{ID: 2, Email: "a"}
+	err := CheckEqual(got, want)
+	var f *Failure
+	True(t, errors.As(err, &f))
+	Equal(t, f.Message, "expected equal")
+	Equal(t, f.Got, any(got))
+	Equal(t, f.Want, any(want))
+	Equal(t, f.Diff, diffValue(t, got, want))
+	Equal(t, err.Error(), "expected equal\n"+f.Diff)
+
+	// the testing.TB version reports the same message
+	atb := &assertTB{TB: t}
+	Equal(atb, got, want)
+	atb.fail(t, "got: "+err.Error())
+
+	dir := t.TempDir()
+	err = CheckEqual(got, want, ArtifactsDir(dir))
+	ErrorContains(t, err, "artifacts:")
+	files, _ := filepath.Glob(filepath.Join(dir, "assert-check_test.go-*.diff.txt"))
+	Len(t, files, 1)
+	data := Must(os.ReadFile(files[0]))
+	True(t, strings.Contains(string(data), "ID:"))
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		fail string
+	}{
+		{"NotEqual", CheckNotEqual(1, 1), "expected not equal, but got equal"},
+		{"Error", CheckError(nil), "expected error, got nil"},
+		{"NoError", CheckNoError(io.EOF), "unexpected error: EOF"},
+		{"ErrorContains", CheckErrorContains(io.EOF, io.ErrClosedPipe), `unexpected error: "EOF" is not *errors.errorString`},
+		{"ErrorContains pass", CheckErrorContains(io.EOF, "E.F"), ""},
+		{"ErrorWant", CheckErrorWant(true, nil), "expected error: got nil"},
+		{"Zero", CheckZero(1), "expected zero, got 1"},
+		{"NotZero", CheckNotZero(""), `expected not zero, got ""`},
+		{"Empty", CheckEmpty("a"), `expected empty, got "a"`},
+		{"NotEmpty", CheckNotEmpty(""), "expected not empty, got empty"},
+		{"Nil", CheckNil(1), "expected nil, got 1"},
+		{"NotNil", CheckNotNil(nil), "expected not nil, got nil"},
+		{"Len", CheckLen("ab", 1), "expected length 1, got 2"},
+		{"Contains", CheckContains("ab", "c"), `expected "ab" to contain "c"`},
+		{"True", CheckTrue(false), "expected true, got false"},
+		{"False", CheckFalse(false), ""},
+		{"Panic", CheckPanic(func() {}), "expected panic, got nothing"},
+		{"Panic pass", CheckPanic(func() { panic(0) }), ""},
+		{"NotPanic", CheckNotPanic(func() { panic("boom") }), "unexpected panic: boom"},
+		{"JSONEq", CheckJSONEq(`{"a": 1}`, `{"a": 2}`), "expected equal JSON\ndiff:\n  $.a: got 1, want 2"},
+		{"JSONEq invalid", CheckJSONEq(`{`, `{}`), "invalid got JSON: unexpected EOF"},
+		{"XMLEq pass", CheckXMLEq(`<a x="1" y="2"/>`, `<a y="2" x="1"/>`), ""},
+		{"CSVEq pass", CheckCSVEq("a,b\n1,2\n", "b,a\n2,1\n"), ""},
+		{"MatchesJSONSchema", CheckMatchesJSONSchema(`1`, `{"type": "string"}`), "JSON does not match schema\n  /: got number, want string (#/type)"},
+		{"JSONPath", CheckJSONPath(`{"a": [1]}`, "$.b", 1), "no match for $.b\nnear $: {\"a\":[1]}"},
+		{"JSONPathExists pass", CheckJSONPathExists(`{"a": [1]}`, "$.a[0]"), ""},
+		{"JSONPathNotExists", CheckJSONPathNotExists(`{"a": [1]}`, "$.a[0]"), "expected no match for $.a[0], got 1\n  $.a[0]: 1"},
+		{"JSONPathCount", CheckJSONPathCount(`{"a": [1]}`, "$.a[*]", 2), "expected 2 matches for $.a[*], got 1\nnear $.a: [1]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.fail == "" {
+				NoError(t, tt.err)
+				return
+			}
+			var f *Failure
+			True(t, errors.As(tt.err, &f))
+			Equal(t, f.Error(), tt.fail)
+		})
+	}
+
+	v, err := CheckTypeAssert[io.Reader](strings.NewReader(""))
+	NoError(t, err)
+	NotNil(t, v)
+	_, err = CheckTypeAssert[io.Reader](1)
+	ErrorContains(t, err, "assertion int.(<nil>) failed")
+
+	// the testing.TB versions report the source expression of the value
+	body := `{"a": 1}`
+	atb := &assertTB{TB: t}
+	JSONEq(atb, body, `{"a": 2}`)
+	atb.fail(t, "body: expected equal JSON")
+
+	atb = &assertTB{TB: t}
+	Panic(atb, func() {})
+	atb.fail(t, "expected panic, got nothing")
+	True(t, strings.HasPrefix(atb.message, "expected panic"))
+
+	Panic(t, func() { _ = CheckEqual(io.EOF, io.EOF) })
+}
@@ -14,25 +14,25 @@ func compareExported() cmp.Option {
 
 // ignoreUnexported returns an [cmp.Option] that only ignores the immediate unexported
 // fields of a struct, including anonymous fields of unexported types.
-func ignoreUnexported() cmp.Option {
+func ignoreUnexported(ex *explainer) cmp.Option {
 	return cmp.FilterPath(
-		func(p cmp.Path) bool {
+		ex.filter("IgnoreUnexported()", func(p cmp.Path) bool {
 			sf, ok := p.Index(-1).(cmp.StructField)
 			if !ok {
 				return false
 			}
 
 			return !token.IsExported(sf.Name())
-		},
+		}),
 		cmp.Ignore(),
 	)
 }
 
 // ignoreEmptyFields returns an [cmp.Option]
 // ignores fields that are empty in the expected value.
-func ignoreEmptyFields() cmp.Option {
+func ignoreEmptyFields(ex *explainer) cmp.Option {
 	return cmp.FilterPath(
-		func(p cmp.Path) bool {
+		ex.filter("SkipEmptyFields()", func(p cmp.Path) bool {
 			sf, ok := p.Index(-1).(cmp.StructField)
 			if !ok {
 				return false
@@ -40,16 +40,16 @@ func ignoreEmptyFields() cmp.Option {
 
 			_, wantv := sf.Values()
 			return isEmptyValue(wantv)
-		},
+		}),
 		cmp.Ignore(),
 	)
 }
 
 // ignoreZeroFields returns an [cmp.Option] that
 // ignores fields that have a zero value.
-func ignoreZeroFields() cmp.Option {
+func ignoreZeroFields(ex *explainer) cmp.Option {
 	return cmp.FilterPath(
-		func(p cmp.Path) bool {
+		ex.filter("SkipZeroFields()", func(p cmp.Path) bool {
 			sf, ok := p.Index(-1).(cmp.StructField)
 			if !ok {
 				return false
@@ -57,7 +57,7 @@ func ignoreZeroFields() cmp.Option {
 
 			_, wantv := sf.Values()
 			return isZeroValue(wantv)
-		},
+		}),
 		cmp.Ignore(),
 	)
 }
@@ -70,7 +70,7 @@ func ignoreZeroFields() cmp.Option {
 //
 // The name may be a dot-delimited string (e.g., "Foo.Bar") to ignore a
 // specific sub-field that is embedded or nested within the parent struct.
-func ignoreFieldNames(typ any, names ...string) cmp.Option {
+func ignoreFieldNames(ex *explainer, typ any, names ...string) cmp.Option {
 	sf := newStructFilter(typ, names...)
-	return cmp.FilterPath(sf.filter, cmp.Ignore())
+	return cmp.FilterPath(ex.filter("SkipFieldNames()", sf.filter), cmp.Ignore())
 }
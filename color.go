@@ -0,0 +1,79 @@
+package assert
+
+import (
+	"flag"
+	"os"
+	"strings"
+)
+
+// ANSI escape sequences used to colorize diffs.
+const (
+	colorRed   = "\x1b[31m"
+	colorGreen = "\x1b[32m"
+	colorDim   = "\x1b[2m"
+	colorReset = "\x1b[0m"
+)
+
+// colorEnabled reports whether failure messages should be colorized.
+//
+// It is controlled by the ASSERT_COLOR environment variable:
+//
+//   - always: colors are always enabled.
+//   - never: colors are always disabled.
+//   - auto (default): colors are enabled if stdout is a terminal,
+//     unless NO_COLOR or CI is set or the test runs with go test -json.
+func colorEnabled() bool {
+	switch os.Getenv("ASSERT_COLOR") {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("CI") != "" || isTest2JSON() {
+		return false
+	}
+
+	return stdoutIsTerminal()
+}
+
+// stdoutIsTerminal reports whether stdout is a terminal.
+// It is a variable, so tests do not depend on how they are run.
+var stdoutIsTerminal = func() bool {
+	_, ok := terminalWidth(os.Stdout)
+	return ok
+}
+
+// isTest2JSON reports whether the test output is converted to JSON
+// by go test -json, which runs the test binary with -test.v=test2json.
+func isTest2JSON() bool {
+	f := flag.Lookup("test.v")
+	return f != nil && f.Value.String() == "test2json"
+}
+
+// colorizeDiff colors removed lines red, added lines green
+// and dims the remaining lines of a diff.
+func colorizeDiff(diff string) string {
+	lines := strings.SplitAfter(diff, "\n")
+	var sb strings.Builder
+	for _, line := range lines {
+		text := strings.TrimSuffix(line, "\n")
+		if text == "" {
+			sb.WriteString(line)
+			continue
+		}
+
+		switch text[0] {
+		case '-':
+			sb.WriteString(colorRed)
+		case '+':
+			sb.WriteString(colorGreen)
+		default:
+			sb.WriteString(colorDim)
+		}
+		sb.WriteString(text)
+		sb.WriteString(colorReset)
+		sb.WriteString(line[len(text):])
+	}
+	return sb.String()
+}
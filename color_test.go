@@ -0,0 +1,70 @@
+package assert
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestMain disables colors, so failure messages checked by tests
+// do not depend on whether stdout is a terminal.
+func TestMain(m *testing.M) {
+	os.Setenv("ASSERT_COLOR", "never")
+	os.Exit(m.Run())
+}
+
+func TestColorEnabled(t *testing.T) {
+	tests := []struct {
+		color    string
+		noColor  string
+		ci       string
+		terminal bool
+		want     bool
+	}{
+		{color: "always", want: true},
+		{color: "always", noColor: "1", want: true},
+		{color: "never", terminal: true, want: false},
+		{color: "auto", noColor: "1", terminal: true, want: false},
+		{color: "auto", ci: "1", terminal: true, want: false},
+		{color: "", terminal: true, want: true},
+		{color: "", want: false},
+	}
+
+	isTerminal := stdoutIsTerminal
+	t.Cleanup(func() { stdoutIsTerminal = isTerminal })
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%s/%s/%t", tt.color, tt.noColor, tt.ci, tt.terminal), func(t *testing.T) {
+			t.Setenv("ASSERT_COLOR", tt.color)
+			t.Setenv("NO_COLOR", tt.noColor)
+			t.Setenv("CI", tt.ci)
+			stdoutIsTerminal = func() bool { return tt.terminal }
+			Equal(t, colorEnabled(), tt.want)
+		})
+	}
+}
+
+func TestColorizeDiff(t *testing.T) {
+	got := colorizeDiff("  T{\n- \tA: 1,\n+ \tA: 2,\n  }\n")
+	want := colorDim + "  T{" + colorReset + "\n" +
+		colorRed + "- \tA: 1," + colorReset + "\n" +
+		colorGreen + "+ \tA: 2," + colorReset + "\n" +
+		colorDim + "  }" + colorReset + "\n"
+	Equal(t, got, want)
+}
+
+func TestEqualColor(t *testing.T) {
+	t.Setenv("ASSERT_COLOR", "always")
+	atb := &assertTB{TB: t}
+	Equal(atb, 1, 2)
+	atb.fail(t, "expected equal")
+	True(t, strings.Contains(atb.message, colorRed+"-"))
+	True(t, strings.Contains(atb.message, "2,"+colorReset))
+
+	t.Setenv("ASSERT_COLOR", "never")
+	atb = &assertTB{TB: t}
+	Equal(atb, 1, 2)
+	atb.fail(t, "expected equal")
+	False(t, strings.Contains(atb.message, "\x1b["))
+}
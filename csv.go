@@ -0,0 +1,387 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// CSVOption configures [CSVEq]. It is implemented by options specific
+// to CSV data and by [EqualOption], so both can be passed to [CSVEq].
+type CSVOption interface {
+	applyCSV(o *csvOptions)
+}
+
+// csvOption is an option specific to CSV data.
+type csvOption func(o *csvOptions)
+
+func (opt csvOption) applyCSV(o *csvOptions) {
+	opt(o)
+}
+
+func (opt EqualOption) applyCSV(o *csvOptions) {
+	opt(o.eq)
+}
+
+// csvOptions are options of [CSVEq].
+type csvOptions struct {
+	eq *equaler
+
+	// keyColumn is the name of the column used to match rows.
+	keyColumn string
+
+	// ignoreRowOrder matches rows regardless of their order.
+	ignoreRowOrder bool
+
+	// comma is the field delimiter.
+	comma rune
+}
+
+func newCSVOptions(opts ...CSVOption) *csvOptions {
+	o := &csvOptions{eq: newEqualer()}
+	for _, opt := range opts {
+		opt.applyCSV(o)
+	}
+	return o
+}
+
+// CSVKeyColumn returns a CSVOption that matches rows
+// in [CSVEq] by the value of the named column instead of their position.
+func CSVKeyColumn(name string) CSVOption {
+	return csvOption(func(o *csvOptions) {
+		o.keyColumn = name
+	})
+}
+
+// CSVIgnoreRowOrder returns a CSVOption that matches
+// rows in [CSVEq] regardless of their order.
+func CSVIgnoreRowOrder() CSVOption {
+	return csvOption(func(o *csvOptions) {
+		o.ignoreRowOrder = true
+	})
+}
+
+// CSVComma returns a CSVOption that sets the field
+// delimiter used by [CSVEq], e.g. '\t' for TSV.
+func CSVComma(r rune) CSVOption {
+	return csvOption(func(o *csvOptions) {
+		o.comma = r
+	})
+}
+
+// CSVEq checks if got and want contain the same CSV data.
+//
+// got and want can be a string, a []byte or an [io.Reader].
+// The first record of each is a header, and cells are matched
+// by column names, so the order of columns does not matter.
+//
+// By default rows are matched by their position. The following options are supported:
+//
+//   - [CSVKeyColumn] matches rows by the value of a column.
+//   - [CSVIgnoreRowOrder] matches rows regardless of their order.
+//   - [CSVComma] sets the field delimiter.
+//   - [SkipFieldNames] ignores columns by name.
+//   - [Tolerance] treats numeric cells within the margin as equal.
+//
+// The failure message is a table of differing rows, where rows from got are
+// marked with "-", rows from want with "+" and changed cells with "^".
+func CSVEq(t testing.TB, got any, want any, opts ...CSVOption) bool {
+	t.Helper()
+	return failed(t, 1, CheckCSVEq(got, want, opts...))
+}
+
+// CheckCSVEq returns a [*Failure] if got and want
+// do not contain the same CSV data, see [CSVEq].
+func CheckCSVEq(got any, want any, opts ...CSVOption) error {
+	o := newCSVOptions(opts...)
+	gott, err := readCSV(got, o.comma)
+	if err != nil {
+		return &Failure{Message: fmt.Sprintf("invalid got CSV: %v", err), Got: got}
+	}
+
+	wantt, err := readCSV(want, o.comma)
+	if err != nil {
+		return &Failure{Message: fmt.Sprintf("invalid want CSV: %v", err), Want: want}
+	}
+
+	cc := &csvComparer{opts: o, eq: o.eq}
+	if diff := cc.compare(gott, wantt); diff != "" {
+		return &Failure{Message: "expected equal CSV", Got: got, Want: want, Diff: diff}
+	}
+	return nil
+}
+
+// csvTable is a parsed CSV document.
+type csvTable struct {
+	header []string
+	rows   [][]string
+}
+
+// cell returns the value of the column in the row.
+func (t *csvTable) cell(row []string, column string) string {
+	i := slices.Index(t.header, column)
+	if i < 0 || i >= len(row) {
+		return ""
+	}
+	return row[i]
+}
+
+func readCSV(src any, comma rune) (*csvTable, error) {
+	var r io.Reader
+	switch src := src.(type) {
+	case string:
+		r = strings.NewReader(src)
+	case []byte:
+		r = bytes.NewReader(src)
+	case io.Reader:
+		r = src
+	default:
+		panic(fmt.Sprintf("CSVEq: unsupported type %T, use string, []byte or io.Reader", src))
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	if comma != 0 {
+		cr.Comma = comma
+	}
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("missing header")
+	}
+	return &csvTable{header: records[0], rows: records[1:]}, nil
+}
+
+type csvComparer struct {
+	opts *csvOptions
+	eq   *equaler
+
+	// columns are compared columns, in order of the want header.
+	columns []string
+}
+
+// csvRowPair is a pair of matched rows, where
+// a missing row has the index -1.
+type csvRowPair struct {
+	got, want int
+}
+
+// compare returns a table of differences between got and want.
+func (cc *csvComparer) compare(got, want *csvTable) string {
+	gotc := cc.visibleColumns(got.header)
+	wantc := cc.visibleColumns(want.header)
+	if !slices.Equal(slices.Sorted(slices.Values(gotc)), slices.Sorted(slices.Values(wantc))) {
+		return fmt.Sprintf("columns differ\n got: %s\nwant: %s", strings.Join(gotc, ", "), strings.Join(wantc, ", "))
+	}
+	cc.columns = wantc
+
+	var pairs []csvRowPair
+	switch {
+	case cc.opts.keyColumn != "":
+		if !slices.Contains(want.header, cc.opts.keyColumn) {
+			panic(fmt.Sprintf("CSVEq: key column %q does not exist", cc.opts.keyColumn))
+		}
+		pairs = cc.matchByKey(got, want)
+	case cc.opts.ignoreRowOrder:
+		pairs = cc.matchUnordered(got, want)
+	default:
+		for i := 0; i < max(len(got.rows), len(want.rows)); i++ {
+			p := csvRowPair{got: -1, want: -1}
+			if i < len(got.rows) {
+				p.got = i
+			}
+			if i < len(want.rows) {
+				p.want = i
+			}
+			pairs = append(pairs, p)
+		}
+	}
+
+	tw := &csvTableWriter{header: append([]string{"row"}, cc.columns...)}
+	for _, p := range pairs {
+		var gotRow, wantRow []string
+		if p.got >= 0 {
+			gotRow = append([]string{strconv.Itoa(p.got + 1)}, cc.cells(got, got.rows[p.got])...)
+		}
+		if p.want >= 0 {
+			wantRow = append([]string{strconv.Itoa(p.want + 1)}, cc.cells(want, want.rows[p.want])...)
+		}
+
+		switch {
+		case gotRow == nil:
+			tw.add("+", wantRow, nil)
+		case wantRow == nil:
+			tw.add("-", gotRow, nil)
+		default:
+			changed := make([]bool, len(tw.header))
+			differ := false
+			for i := 1; i < len(changed); i++ {
+				changed[i] = !cc.equalCell(gotRow[i], wantRow[i])
+				differ = differ || changed[i]
+			}
+			if differ {
+				tw.add("-", gotRow, nil)
+				tw.add("+", wantRow, changed)
+			}
+		}
+	}
+
+	if len(tw.rows) == 0 {
+		return ""
+	}
+	return tw.String()
+}
+
+// visibleColumns returns the header without ignored columns.
+func (cc *csvComparer) visibleColumns(header []string) []string {
+	var out []string
+	for _, name := range header {
+		if !slices.Contains(cc.eq.skipFieldNames, name) {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+func (cc *csvComparer) cells(t *csvTable, row []string) []string {
+	out := make([]string, 0, len(cc.columns))
+	for _, column := range cc.columns {
+		out = append(out, t.cell(row, column))
+	}
+	return out
+}
+
+func (cc *csvComparer) equalCell(a, b string) bool {
+	return a == b || (cc.eq.tolerance > 0 && withinTolerance(a, b, cc.eq.tolerance))
+}
+
+func (cc *csvComparer) equalRow(got, want *csvTable, gotRow, wantRow []string) bool {
+	for _, column := range cc.columns {
+		if !cc.equalCell(got.cell(gotRow, column), want.cell(wantRow, column)) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchByKey pairs rows with the same key column value.
+func (cc *csvComparer) matchByKey(got, want *csvTable) []csvRowPair {
+	keys := map[string][]int{}
+	for i, row := range got.rows {
+		key := got.cell(row, cc.opts.keyColumn)
+		keys[key] = append(keys[key], i)
+	}
+
+	used := make([]bool, len(got.rows))
+	var pairs []csvRowPair
+	for i, row := range want.rows {
+		p := csvRowPair{got: -1, want: i}
+		key := want.cell(row, cc.opts.keyColumn)
+		if rows := keys[key]; len(rows) > 0 {
+			p.got, keys[key] = rows[0], rows[1:]
+			used[p.got] = true
+		}
+		pairs = append(pairs, p)
+	}
+	return appendUnused(pairs, used)
+}
+
+// matchUnordered pairs equal rows regardless of their position.
+func (cc *csvComparer) matchUnordered(got, want *csvTable) []csvRowPair {
+	used := make([]bool, len(got.rows))
+	var pairs []csvRowPair
+	for i, wantRow := range want.rows {
+		p := csvRowPair{got: -1, want: i}
+		for j, gotRow := range got.rows {
+			if !used[j] && cc.equalRow(got, want, gotRow, wantRow) {
+				p.got = j
+				used[j] = true
+				break
+			}
+		}
+		pairs = append(pairs, p)
+	}
+	return appendUnused(pairs, used)
+}
+
+// appendUnused appends got rows that were not paired with any want row.
+func appendUnused(pairs []csvRowPair, used []bool) []csvRowPair {
+	for i, ok := range used {
+		if !ok {
+			pairs = append(pairs, csvRowPair{got: i, want: -1})
+		}
+	}
+	return pairs
+}
+
+// csvTableWriter renders rows as an aligned table.
+type csvTableWriter struct {
+	header []string
+	rows   []csvTableRow
+}
+
+type csvTableRow struct {
+	mark    string
+	cells   []string
+	changed []bool
+}
+
+func (tw *csvTableWriter) add(mark string, cells []string, changed []bool) {
+	tw.rows = append(tw.rows, csvTableRow{mark: mark, cells: cells, changed: changed})
+}
+
+func (tw *csvTableWriter) String() string {
+	widths := make([]int, len(tw.header))
+	for i, h := range tw.header {
+		widths[i] = len(h)
+	}
+	for _, row := range tw.rows {
+		for i, c := range row.cells {
+			widths[i] = max(widths[i], len(c))
+		}
+	}
+
+	var sb strings.Builder
+	line := func(mark string, cells []string, sep string) {
+		var lb strings.Builder
+		lb.WriteString(mark)
+		for i, c := range cells {
+			if i > 0 {
+				lb.WriteString(sep)
+			} else {
+				lb.WriteString(" ")
+			}
+			lb.WriteString(c)
+			lb.WriteString(strings.Repeat(" ", widths[i]-len(c)))
+		}
+		sb.WriteString(strings.TrimRight(lb.String(), " "))
+		sb.WriteString("\n")
+	}
+
+	line(" ", tw.header, " | ")
+	for _, row := range tw.rows {
+		line(row.mark, row.cells, " | ")
+		if !slices.Contains(row.changed, true) {
+			continue
+		}
+
+		markers := make([]string, len(row.cells))
+		for i := range markers {
+			char := " "
+			if row.changed[i] {
+				char = "^"
+			}
+			markers[i] = strings.Repeat(char, widths[i])
+		}
+		line(" ", markers, "   ")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,118 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCSVEq(t *testing.T) {
+	const want = "id,name,price\n1,apple,12.5\n2,bread,3\n3,milk,2.25\n"
+
+	tests := []struct {
+		name string
+		got  string
+		opts []CSVOption
+		fail string
+	}{
+		{
+			name: "equal",
+			got:  want,
+		},
+		{
+			name: "column order",
+			got:  "name,id,price\napple,1,12.5\nbread,2,3\nmilk,3,2.25\n",
+		},
+		{
+			name: "changed cell",
+			got:  "id,name,price\n1,apple,12.5\n2,bread,3.5\n3,milk,2.25\n",
+			fail: "" +
+				"  row | id | name  | price\n" +
+				"- 2   | 2  | bread | 3.5\n" +
+				"+ 2   | 2  | bread | 3\n" +
+				"                     ^^^^^",
+		},
+		{
+			name: "missing and extra rows",
+			got:  "id,name,price\n1,apple,12.5\n2,bread,3\n",
+			fail: "+ 3   | 3  | milk | 2.25",
+		},
+		{
+			name: "columns differ",
+			got:  "id,name\n1,apple\n",
+			fail: "columns differ\n got: id, name\nwant: id, name, price",
+		},
+		{
+			name: "skip columns",
+			got:  "id,name,price\n4,apple,12.5\n5,bread,3\n6,milk,2.25\n",
+			opts: []CSVOption{SkipFieldNames("id")},
+		},
+		{
+			name: "key column",
+			got:  "id,name,price\n3,milk,2.25\n1,apple,12.5\n2,bread,3\n",
+			opts: []CSVOption{CSVKeyColumn("id")},
+		},
+		{
+			name: "key column changed",
+			got:  "id,name,price\n3,milk,2.25\n1,apple,12.5\n4,bread,3\n",
+			opts: []CSVOption{CSVKeyColumn("id")},
+			fail: "+ 2   | 2  | bread | 3\n- 3   | 4  | bread | 3",
+		},
+		{
+			name: "ignore row order",
+			got:  "id,name,price\n3,milk,2.25\n2,bread,3\n1,apple,12.5\n",
+			opts: []CSVOption{CSVIgnoreRowOrder()},
+		},
+		{
+			name: "tolerance",
+			got:  "id,name,price\n1,apple,12.501\n2,bread,3\n3,milk,2.249\n",
+			opts: []CSVOption{Tolerance(0.01)},
+		},
+		{
+			name: "tsv",
+			got:  "id\tname\tprice\n1\tapple\t12.5\n2\tbread\t3\n3\tmilk\t2.25\n",
+			fail: "columns differ",
+		},
+		{
+			name: "invalid got",
+			got:  "id,\"name\n",
+			fail: "invalid got CSV",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atb := &assertTB{TB: t}
+			CSVEq(atb, tt.got, want, tt.opts...)
+			atb.check(t, tt.fail)
+		})
+	}
+
+	atb := &assertTB{TB: t}
+	CSVEq(atb, strings.NewReader("a\tb\n1\t2\n"), []byte("b\ta\n2\t1\n"), CSVComma('\t'))
+	atb.pass(t)
+
+	Panic(t, func() {
+		CSVEq(t, 0, want)
+	})
+	Panic(t, func() {
+		CSVEq(t, want, want, CSVKeyColumn("x"))
+	})
+}
+
+func TestEqualTolerance(t *testing.T) {
+	atb := &assertTB{TB: t}
+	Equal(atb, []float64{1, 2.0001}, []float64{1, 2}, Tolerance(0.001))
+	atb.pass(t)
+
+	atb = &assertTB{TB: t}
+	Equal(atb, 2.1, 2, Tolerance(0.001))
+	atb.fail(t, "expected equal")
+
+	atb = &assertTB{TB: t}
+	JSONEq(atb, `{"a": 0.30000000000000004}`, `{"a": 0.3}`, Tolerance(1e-9))
+	atb.pass(t)
+
+	Panic(t, func() {
+		Tolerance(-1)
+	})
+}
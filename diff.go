@@ -0,0 +1,128 @@
+package assert
+
+import "slices"
+
+// maxEditWork bounds the work of computing an edit script, measured as
+// the number of differing elements times the edit distance. Both the
+// time and the memory of the Myers' algorithm grow with it, so larger
+// inputs are not aligned and callers fall back to simpler diffs.
+const maxEditWork = 1 << 24
+
+// edit is a single operation of an edit script.
+type edit struct {
+	op byte // ' ' for equal, '-' for deleted from a, '+' for inserted from b
+	a  int  // index in a, -1 for insertions
+	b  int  // index in b, -1 for deletions
+}
+
+// editScript returns the shortest edit script transforming a into b,
+// computed with the Myers' difference algorithm. ok is false if the
+// script is too expensive to compute, see maxEditWork.
+func editScript[T comparable](a, b []T) (edits []edit, ok bool) {
+	// the common prefix does not count towards the limit; the common
+	// suffix does, as trimming it would change which lines are paired
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	rest, ok := shortestEdits(a[prefix:], b[prefix:])
+	if !ok {
+		return nil, false
+	}
+
+	edits = make([]edit, 0, prefix+len(rest))
+	for i := range prefix {
+		edits = append(edits, edit{op: ' ', a: i, b: i})
+	}
+	for _, e := range rest {
+		if e.a >= 0 {
+			e.a += prefix
+		}
+		if e.b >= 0 {
+			e.b += prefix
+		}
+		edits = append(edits, e)
+	}
+	return edits, true
+}
+
+// shortestEdits returns the shortest edit script transforming a into b,
+// or false if its edit distance exceeds the limit of maxEditWork.
+func shortestEdits[T comparable](a, b []T) ([]edit, bool) {
+	n, m := len(a), len(b)
+	maxD := n + m
+	if n+m > 0 {
+		maxD = min(maxD, maxEditWork/(n+m))
+	}
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+
+	// trace holds v[-d:d+1] for every d, needed to backtrack the path.
+	var trace [][]int
+	for d := 0; ; d++ {
+		if d > maxD {
+			return nil, false
+		}
+
+		found := false
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+		trace = append(trace, slices.Clone(v[offset-d:offset+d+1]))
+		if found {
+			break
+		}
+	}
+
+	// backtrack from the end to collect edits in reverse
+	var edits []edit
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		k := x - y
+		prevK, prevX := 0, 0
+		if d > 0 {
+			// vp returns v[k] of the previous d
+			vp := func(k int) int { return trace[d-1][k+d-1] }
+			if k == -d || (k != d && vp(k-1) < vp(k+1)) {
+				prevK = k + 1
+			} else {
+				prevK = k - 1
+			}
+			prevX = vp(prevK)
+		}
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, edit{op: ' ', a: x, b: y})
+		}
+		if d > 0 {
+			if x == prevX {
+				edits = append(edits, edit{op: '+', a: -1, b: prevY})
+			} else {
+				edits = append(edits, edit{op: '-', a: prevX, b: -1})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	slices.Reverse(edits)
+	return edits, true
+}
@@ -0,0 +1,52 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEditScript(t *testing.T) {
+	render := func(a, b string) string {
+		as, bs := strings.Split(a, ""), strings.Split(b, "")
+		edits, ok := editScript(as, bs)
+		True(t, ok)
+
+		var sb strings.Builder
+		for _, e := range edits {
+			switch e.op {
+			case '+':
+				sb.WriteString("+" + bs[e.b])
+			case '-':
+				sb.WriteString("-" + as[e.a])
+			default:
+				sb.WriteString(as[e.a])
+			}
+		}
+		return sb.String()
+	}
+
+	Equal(t, render("", ""), "")
+	Equal(t, render("abc", "abc"), "abc")
+	Equal(t, render("abc", ""), "-a-b-c")
+	Equal(t, render("", "abc"), "+a+b+c")
+	Equal(t, render("abcabba", "cbabac"), "-a-bc+bab-ba+c")
+}
+
+func TestEditScriptLimit(t *testing.T) {
+	a := make([]int, 20000)
+	b := make([]int, 20000)
+	for i := range a {
+		a[i] = i
+		b[i] = -i - 1
+	}
+
+	// values too different to be aligned in bounded time and memory
+	_, ok := editScript(a, b)
+	False(t, ok)
+
+	// the common prefix does not count
+	b = append(append([]int(nil), a...), -1)
+	edits, ok := editScript(a, b)
+	True(t, ok)
+	Len(t, edits, len(b))
+}
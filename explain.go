@@ -0,0 +1,115 @@
+package assert
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Explain returns an EqualOption that logs each path ignored by
+// [IgnoreUnexported], [SkipEmptyFields], [SkipZeroFields] and
+// [SkipFieldNames], with whether the ignored values differ.
+// It helps to spot options hiding real differences when [Equal] passes.
+//
+// It can be enabled for all assertions by setting
+// the ASSERT_EXPLAIN environment variable to "1".
+func Explain() EqualOption {
+	return func(o *equaler) {
+		o.explain = true
+	}
+}
+
+// explaining reports whether ignored paths are logged.
+func (o *equaler) explaining() bool {
+	return o.explain || os.Getenv("ASSERT_EXPLAIN") == "1"
+}
+
+// explainer records paths ignored by options.
+type explainer struct {
+	seen    map[string]bool
+	entries []string
+}
+
+// filter returns f recording the paths it ignores with the option name.
+// If ex is nil, f is returned as it is.
+func (ex *explainer) filter(option string, f func(cmp.Path) bool) func(cmp.Path) bool {
+	if ex == nil {
+		return f
+	}
+	return func(p cmp.Path) bool {
+		ignored := f(p)
+		if ignored {
+			ex.record(option, p)
+		}
+		return ignored
+	}
+}
+
+func (ex *explainer) record(option string, p cmp.Path) {
+	// cmp may evaluate filters of a path more than once
+	key := option + p.GoString()
+	if ex.seen[key] {
+		return
+	}
+	ex.seen[key] = true
+
+	name := pathString(p)
+	if name == "" {
+		name = "value"
+	}
+	ex.entries = append(ex.entries, fmt.Sprintf("%s ignored by %s: %s", name, option, compareIgnored(p.Last().Values())))
+}
+
+// compareIgnored describes whether ignored values differ.
+func compareIgnored(vx, vy reflect.Value) (result string) {
+	if !vx.IsValid() || !vy.IsValid() {
+		if vx.IsValid() == vy.IsValid() {
+			return "values equal"
+		}
+		return "values differ"
+	}
+
+	x, okx := interfaceOf(vx)
+	y, oky := interfaceOf(vy)
+	if !okx || !oky {
+		// values of unexported fields are compared by their Go syntax
+		eq := newEqualer()
+		if eq.sprintLine(vx, "", "", nil) == eq.sprintLine(vy, "", "", nil) {
+			return "values equal"
+		}
+		return "values differ"
+	}
+
+	defer func() {
+		if recover() != nil {
+			result = "values not comparable"
+		}
+	}()
+	if cmp.Equal(x, y, compareExported()) {
+		return "values equal"
+	}
+	return "values differ"
+}
+
+// explain logs paths of got and want ignored by options if enabled.
+func explain[V any](t testing.TB, got, want V, opts ...EqualOption) {
+	t.Helper()
+	eq := newEqualer(opts...)
+	if !eq.explaining() {
+		return
+	}
+
+	eq.explainer = &explainer{seen: map[string]bool{}}
+	var zero V
+	cmp.Equal(got, want, eq.cmpOptions(zero)...)
+
+	if len(eq.explainer.entries) == 0 {
+		t.Logf("explain: no paths ignored by options")
+	}
+	for _, entry := range eq.explainer.entries {
+		t.Logf("explain: %s", entry)
+	}
+}
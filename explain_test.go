@@ -0,0 +1,52 @@
+package assert
+
+import (
+	"fmt"
+	"testing"
+)
+
+// logTB records messages logged with Logf.
+type logTB struct {
+	assertTB
+	logs []string
+}
+
+func (ltb *logTB) Logf(format string, args ...any) {
+	ltb.logs = append(ltb.logs, fmt.Sprintf(format, args...))
+}
+
+func TestEqualExplain(t *testing.T) {
+	type Profile struct {
+		Bio string
+	}
+	type User struct {
+		ID      int
+		Email   string
+		Profile Profile
+		secret  string
+	}
+
+	got := User{ID: 1, Email: "a@example.com", Profile: Profile{Bio: "x"}, secret: "a"}
+	want := User{ID: 2, secret: "a"}
+
+	ltb := &logTB{assertTB: assertTB{TB: t}}
+	Equal(ltb, got, want, SkipEmptyFields(), SkipFieldNames("ID"), IgnoreUnexported(), Explain())
+	ltb.pass(t)
+	Equal(t, ltb.logs, []string{
+		"explain: User.ID ignored by SkipFieldNames(): values differ",
+		"explain: User.Email ignored by SkipEmptyFields(): values differ",
+		"explain: User.Profile ignored by SkipEmptyFields(): values differ",
+		"explain: User.secret ignored by IgnoreUnexported(): values equal",
+	})
+
+	t.Setenv("ASSERT_EXPLAIN", "1")
+	ltb = &logTB{assertTB: assertTB{TB: t}}
+	Equal(ltb, 1, 1)
+	Equal(t, ltb.logs, []string{"explain: no paths ignored by options"})
+
+	// without explain nothing is logged
+	t.Setenv("ASSERT_EXPLAIN", "")
+	ltb = &logTB{assertTB: assertTB{TB: t}}
+	Equal(ltb, got, want, SkipEmptyFields(), SkipFieldNames("ID"), IgnoreUnexported())
+	Len(t, ltb.logs, 0)
+}
@@ -0,0 +1,139 @@
+package assert
+
+import (
+	"encoding"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+	"unsafe"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// formatter formats a value of a registered type.
+type formatter func(v any) string
+
+// formatters are formatters registered with [RegisterFormatter], by type.
+var formatters sync.Map
+
+// RegisterFormatter registers fn as the formatter of values of type T
+// in failure messages of all assertions, e.g. to print decimals as "12.50".
+// It is usually called in TestMain or in an init function.
+//
+// Values are still compared as they are, formatters are only used
+// to print them. Differing values of type T are listed below the diff
+// with their paths, see [PathList].
+func RegisterFormatter[T any](fn func(T) string) {
+	formatters.Store(reflect.TypeFor[T](), formatterOf(fn))
+}
+
+// FormatWith returns an EqualOption that formats values of type T with fn,
+// taking precedence over formatters registered with [RegisterFormatter].
+func FormatWith[T any](fn func(T) string) EqualOption {
+	return func(o *equaler) {
+		if o.formatters == nil {
+			o.formatters = map[reflect.Type]formatter{}
+		}
+		o.formatters[reflect.TypeFor[T]()] = formatterOf(fn)
+	}
+}
+
+// FormatStringers returns an EqualOption that formats values of types
+// without formatters with their String or MarshalText method.
+//
+// It can be enabled for all assertions by setting
+// the ASSERT_FORMAT_STRINGERS environment variable to "1".
+func FormatStringers() EqualOption {
+	return func(o *equaler) {
+		o.formatStringers = true
+	}
+}
+
+func formatterOf[T any](fn func(T) string) formatter {
+	return func(v any) string { return fn(v.(T)) }
+}
+
+var (
+	stringerType      = reflect.TypeFor[fmt.Stringer]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// formatter returns the formatter of values of type t.
+func (o *equaler) formatter(t reflect.Type) (formatter, bool) {
+	if f, ok := o.formatters[t]; ok {
+		return f, true
+	}
+	if f, ok := formatters.Load(t); ok {
+		return f.(formatter), true
+	}
+	if !o.formatStringers && os.Getenv("ASSERT_FORMAT_STRINGERS") != "1" {
+		return nil, false
+	}
+
+	switch {
+	case t.Kind() == reflect.Interface:
+		// formatted by the dynamic type
+		return nil, false
+	case t.Implements(stringerType):
+		return func(v any) string { return v.(fmt.Stringer).String() }, true
+	case t.Implements(textMarshalerType):
+		return func(v any) string {
+			text, err := v.(encoding.TextMarshaler).MarshalText()
+			if err != nil {
+				panic(err)
+			}
+			return string(text)
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+// format formats v if its type has a formatter.
+// Formatters that panic, e.g. on nil pointers, are skipped.
+func (o *equaler) format(v reflect.Value) (s string, ok bool) {
+	if !v.IsValid() {
+		return "", false
+	}
+	f, ok := o.formatter(v.Type())
+	if !ok {
+		return "", false
+	}
+	x, ok := interfaceOf(v)
+	if !ok {
+		return "", false
+	}
+
+	defer func() {
+		if recover() != nil {
+			s, ok = "", false
+		}
+	}()
+	return f(x), true
+}
+
+// interfaceOf returns the value held by v,
+// also if v was obtained from an unexported field.
+func interfaceOf(v reflect.Value) (any, bool) {
+	if v.CanInterface() {
+		return v.Interface(), true
+	}
+	if v.CanAddr() {
+		return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem().Interface(), true
+	}
+	return nil, false
+}
+
+// formatPrefix returns the shortest prefix of p
+// pointing to a value of a type with a formatter.
+func (o *equaler) formatPrefix(p cmp.Path) (cmp.Path, bool) {
+	for i := 1; i <= len(p); i++ {
+		if t := p.Index(i - 1).Type(); t != nil {
+			if _, ok := o.formatter(t); ok {
+				return p[:i], true
+			}
+		}
+	}
+	return nil, false
+}
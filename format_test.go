@@ -0,0 +1,75 @@
+package assert
+
+import (
+	"fmt"
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+type testDecimal struct {
+	units int64
+	scale int
+}
+
+func (d testDecimal) String() string {
+	return fmt.Sprintf("%d.%02d", d.units/100, d.units%100)
+}
+
+type testUUID [4]byte
+
+func TestEqualFormatWith(t *testing.T) {
+	type Order struct {
+		ID    testUUID
+		Price testDecimal
+		Note  string
+	}
+
+	formatUUID := func(id testUUID) string { return fmt.Sprintf("%x-%x", id[:2], id[2:]) }
+	got := Order{ID: testUUID{1, 2, 3, 4}, Price: testDecimal{1250, 2}, Note: "a"}
+	want := Order{ID: testUUID{1, 2, 3, 5}, Price: testDecimal{1260, 2}, Note: "b"}
+
+	atb := &assertTB{TB: t}
+	Equal(atb, got, want, FormatWith(formatUUID), FormatWith(testDecimal.String))
+	atb.fail(t, "Order.ID: got \"0102-0304\", want \"0102-0305\"\nOrder.Price: got \"12.50\", want \"12.60\"\n")
+
+	// values are still compared as they are
+	atb = &assertTB{TB: t}
+	Equal(atb, testDecimal{1250, 2}, testDecimal{1250, 3}, FormatWith(testDecimal.String))
+	atb.fail(t, "diff:\ntestDecimal: got \"12.50\", want \"12.50\"\n")
+
+	atb = &assertTB{TB: t}
+	Equal(atb, got, want, FormatWith(formatUUID), SideBySide(), DiffWidth(80))
+	atb.fail(t, `"0102-0304"`)
+}
+
+func TestRegisterFormatter(t *testing.T) {
+	type Key [2]byte
+	RegisterFormatter(func(k Key) string { return fmt.Sprintf("key-%d", k[0]) })
+	t.Cleanup(func() { formatters.Delete(reflect.TypeFor[Key]()) })
+
+	atb := &assertTB{TB: t}
+	NotZero(atb, Key{})
+	atb.fail(t, `expected not zero, got "key-0"`)
+
+	atb = &assertTB{TB: t}
+	Equal(atb, []Key{{1}}, []Key{{2}}, PathList())
+	atb.fail(t, "diff:\n[0]: got \"key-1\", want \"key-2\"\n")
+}
+
+func TestFormatStringers(t *testing.T) {
+	type Host struct {
+		Addr netip.Addr
+	}
+	got := Host{Addr: netip.MustParseAddr("10.0.0.1")}
+	want := Host{Addr: netip.MustParseAddr("10.0.0.2")}
+
+	atb := &assertTB{TB: t}
+	Equal(atb, got, want, FormatStringers())
+	atb.fail(t, "Host.Addr: got \"10.0.0.1\", want \"10.0.0.2\"\n")
+
+	t.Setenv("ASSERT_FORMAT_STRINGERS", "1")
+	atb = &assertTB{TB: t}
+	Nil(atb, &want)
+	atb.fail(t, "expected nil, got &assert.Host{\n\tAddr: \"10.0.0.2\",\n}")
+}
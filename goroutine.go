@@ -0,0 +1,81 @@
+package assert
+
+import (
+	"runtime"
+	"runtime/debug"
+	"testing"
+)
+
+// Go runs fn in a new goroutine with a testing.TB that is safe to use
+// outside of the test goroutine. Failed assertions are reported with
+// Errorf and stop only the goroutine, as the testing package does not
+// allow Fatalf to be called from other goroutines. Panics in fn are
+// reported as failures too.
+//
+// Go returns a function that waits for fn to return. The test waits
+// for fn in its cleanup, so failures are never reported after the test
+// has finished.
+//
+//	wait := assert.Go(t, func(t testing.TB) {
+//		resp, err := http.Get(url)
+//		assert.NoError(t, err)
+//		assert.Equal(t, resp.StatusCode, http.StatusOK)
+//	})
+//	wait()
+func Go(t testing.TB, fn func(t testing.TB)) (wait func()) {
+	t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			// runtime.Goexit does not panic, so r is nil when fn stops on failure
+			if r := recover(); r != nil {
+				t.Errorf("panic in goroutine: %v\n%s", r, debug.Stack())
+			}
+		}()
+		fn(&goTB{TB: t})
+	}()
+
+	wait = func() { <-done }
+	t.Cleanup(wait)
+	return wait
+}
+
+// goTB is a testing.TB used outside of the test goroutine.
+type goTB struct {
+	testing.TB
+}
+
+func (g *goTB) Fatal(args ...any) {
+	g.TB.Helper()
+	g.TB.Error(args...)
+	runtime.Goexit()
+}
+
+func (g *goTB) Fatalf(format string, args ...any) {
+	g.TB.Helper()
+	g.TB.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+func (g *goTB) FailNow() {
+	g.TB.Fail()
+	runtime.Goexit()
+}
+
+func (g *goTB) Skip(args ...any) {
+	g.TB.Helper()
+	g.TB.Log(args...)
+	runtime.Goexit()
+}
+
+func (g *goTB) Skipf(format string, args ...any) {
+	g.TB.Helper()
+	g.TB.Logf(format, args...)
+	runtime.Goexit()
+}
+
+func (g *goTB) SkipNow() {
+	runtime.Goexit()
+}
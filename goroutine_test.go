@@ -0,0 +1,69 @@
+package assert
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// syncTB records messages reported with Errorf from many goroutines,
+// and with Fatalf.
+type syncTB struct {
+	testing.TB
+
+	mu     sync.Mutex
+	errors []string
+}
+
+func (stb *syncTB) Helper() {}
+
+func (stb *syncTB) Errorf(format string, args ...any) {
+	stb.mu.Lock()
+	defer stb.mu.Unlock()
+	stb.errors = append(stb.errors, fmt.Sprintf(format, args...))
+}
+
+func (stb *syncTB) Fatalf(format string, args ...any) {
+	stb.Errorf(format, args...)
+}
+
+func TestGo(t *testing.T) {
+	stb := &syncTB{TB: t}
+
+	var waits []func()
+	for i := range 10 {
+		waits = append(waits, Go(stb, func(t testing.TB) {
+			Equal(t, i%2, 0)
+			t.Errorf("not reached %d", i)
+		}))
+	}
+	for _, wait := range waits {
+		wait()
+	}
+
+	slices.Sort(stb.errors)
+	failure := "i%2: expected equal\n" + diffValue(t, 1, 0)
+	Equal(t, stb.errors, []string{
+		failure, failure, failure, failure, failure,
+		"not reached 0", "not reached 2", "not reached 4", "not reached 6", "not reached 8",
+	})
+}
+
+func TestGoPanic(t *testing.T) {
+	stb := &syncTB{TB: t}
+	Go(stb, func(t testing.TB) { panic("boom") })()
+	Len(t, stb.errors, 1)
+	ErrorContains(t, fmt.Errorf("%s", stb.errors[0]), "^panic in goroutine: boom\n")
+}
+
+func TestGoSoft(t *testing.T) {
+	stb := &syncTB{TB: t}
+	Go(stb, func(t testing.TB) {
+		st := Soft(t)
+		True(st, false)
+		False(st, true)
+	})()
+	slices.Sort(stb.errors)
+	Equal(t, stb.errors, []string{"expected false, got true", "expected true, got false"})
+}
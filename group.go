@@ -0,0 +1,154 @@
+package assert
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// All runs fn and reports failures of all assertions made against
+// the collector in a single Fatalf, with numbered entries and their
+// call sites. Assertions made against c do not stop fn, and return
+// false when they fail, so dependent checks can be guarded.
+//
+//	assert.All(t, func(c *assert.C) {
+//		assert.Equal(c, resp.StatusCode, http.StatusOK)
+//		c.Group("headers", func(c *assert.C) {
+//			assert.Equal(c, resp.Header.Get("Content-Type"), "application/json")
+//		})
+//	})
+//
+// All returns true if no assertion failed.
+func All(t testing.TB, fn func(c *C)) bool {
+	t.Helper()
+
+	c := &C{TB: t, failures: &groupFailures{}}
+	fn(c)
+	if c.failures.len() == 0 {
+		return true
+	}
+	t.Fatalf("%s", c.report())
+	return false
+}
+
+// C collects failures of assertions in a group, see [All].
+type C struct {
+	testing.TB
+
+	// label is the slash-separated label of the group,
+	// empty for the top-level group.
+	label string
+
+	// failures are shared with the parent groups.
+	failures *groupFailures
+}
+
+// groupFailures are failures of a group and its subgroups,
+// which may be recorded from many goroutines, see [Go].
+type groupFailures struct {
+	mu   sync.Mutex
+	list []groupFailure
+}
+
+func (g *groupFailures) add(f groupFailure) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.list = append(g.list, f)
+}
+
+func (g *groupFailures) len() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.list)
+}
+
+func (g *groupFailures) all() []groupFailure {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return slices.Clone(g.list)
+}
+
+// groupFailure is a failure reported in a group.
+type groupFailure struct {
+	label   string
+	file    string
+	line    int
+	message string
+}
+
+// Group runs fn with a collector whose failures are labeled
+// with label and reported together with failures of c.
+// Group returns true if no assertion in fn failed.
+func (c *C) Group(label string, fn func(c *C)) bool {
+	if c.label != "" {
+		label = c.label + "/" + label
+	}
+
+	n := c.failures.len()
+	fn(&C{TB: c.TB, label: label, failures: c.failures})
+	return c.failures.len() == n
+}
+
+// Failed reports whether an assertion in the group
+// or the test itself has failed.
+func (c *C) Failed() bool {
+	return c.failures.len() > 0 || c.TB.Failed()
+}
+
+func (c *C) Error(args ...any) {
+	c.record(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+func (c *C) Errorf(format string, args ...any) {
+	c.record(fmt.Sprintf(format, args...))
+}
+
+func (c *C) Fatal(args ...any) {
+	c.record(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+func (c *C) Fatalf(format string, args ...any) {
+	c.record(fmt.Sprintf(format, args...))
+}
+
+func (c *C) Fail() {
+	c.record("failed")
+}
+
+func (c *C) FailNow() {
+	c.record("failed")
+}
+
+func (c *C) record(message string) {
+	f := groupFailure{label: c.label, message: message}
+	if _, file, line, ok := callSite(); ok {
+		f.file, f.line = file, line
+	}
+	c.failures.add(f)
+}
+
+// report returns the failure message of the group.
+func (c *C) report() string {
+	failures := c.failures.all()
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s failed", plural(len(failures), "assertion"))
+	for i, f := range failures {
+		prefix := "[" + strconv.Itoa(i+1) + "] "
+		sb.WriteString("\n" + prefix)
+		if f.label != "" {
+			sb.WriteString(f.label + ": ")
+		}
+		if f.file != "" {
+			fmt.Fprintf(&sb, "%s:%d: ", filepath.Base(f.file), f.line)
+		}
+		indent := "\n" + strings.Repeat(" ", len(prefix))
+		message := strings.TrimRight(f.message, "\n")
+		sb.WriteString(strings.ReplaceAll(message, "\n", indent))
+	}
+	return sb.String()
+}
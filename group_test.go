@@ -0,0 +1,70 @@
+package assert
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestAll(t *testing.T) {
+	atb := &assertTB{TB: t}
+	ok := All(atb, func(c *C) {
+		Equal(c, 1, 1)
+		True(c, true)
+	})
+	atb.pass(t)
+	True(t, ok)
+
+	var line int
+	got := []int{1, 2}
+	atb = &assertTB{TB: t}
+	ok = All(atb, func(c *C) {
+		_, _, line, _ = runtime.Caller(0)
+		Equal(c, got[0], 1)
+		Len(c, got, 3)
+		passed := c.Group("items", func(c *C) {
+			True(c, got[1] == 3)
+			c.Group("first", func(c *C) {
+				c.Errorf("custom\nmessage")
+			})
+		})
+		False(c, passed)
+		True(c, c.Failed())
+	})
+	False(t, ok)
+	atb.fail(t, fmt.Sprintf(`3 assertions failed
+[1] group_test.go:%d: got: expected length 3, got 2
+[2] items: group_test.go:%d: got[1] == 3: expected true, got false
+[3] items/first: group_test.go:%d: custom
+    message`, line+2, line+4, line+6))
+}
+
+func TestAllGoroutines(t *testing.T) {
+	stb := &syncTB{TB: t}
+	All(stb, func(c *C) {
+		var waits []func()
+		for range 8 {
+			waits = append(waits, Go(c, func(t testing.TB) {
+				True(t, false)
+			}))
+		}
+		for _, wait := range waits {
+			wait()
+		}
+	})
+	Len(t, stb.errors, 1)
+	True(t, strings.HasPrefix(stb.errors[0], "8 assertions failed\n"))
+}
+
+func TestAllDiffs(t *testing.T) {
+	atb := &assertTB{TB: t}
+	All(atb, func(c *C) {
+		Equal(c, []int{1}, []int{2})
+		Equal(c, 1, 2)
+	})
+	atb.fail(t, "2 assertions failed\n[1] ")
+	for _, line := range strings.Split(atb.message, "\n") {
+		NotEqual(t, strings.TrimSpace(line), "")
+	}
+}
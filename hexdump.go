@@ -0,0 +1,312 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// hexRowBytes is the number of bytes in a row of hexdump diffs.
+const hexRowBytes = 16
+
+// maxAlignBytes is the maximum total length of differing parts
+// aligned with an edit script. Longer parts are compared byte by byte.
+const maxAlignBytes = 4096
+
+// HexContext returns an EqualOption that limits hexdump diffs
+// to rows with bytes at most n bytes away from a mismatch.
+//
+// Byte slices and arrays that do not hold valid UTF-8 text are rendered
+// as hexdumps, also when they are nested in other values.
+// By default all rows are shown.
+func HexContext(n int) EqualOption {
+	return func(o *equaler) {
+		o.hexContext = &n
+	}
+}
+
+// hexContextBytes returns the number of bytes shown
+// around mismatches in hexdump diffs, or -1 for all.
+func (o *equaler) hexContextBytes() int {
+	if o.hexContext != nil {
+		return max(*o.hexContext, 0)
+	}
+	return -1
+}
+
+// bytesValue returns the bytes held by v if it is a byte slice or array.
+func bytesValue(v reflect.Value) ([]byte, bool) {
+	if !v.IsValid() || (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || v.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, false
+	}
+	if v.Kind() == reflect.Slice {
+		return v.Bytes(), true
+	}
+
+	// arrays are not always addressable
+	b := make([]byte, v.Len())
+	for i := range b {
+		b[i] = byte(v.Index(i).Uint())
+	}
+	return b, true
+}
+
+// binaryBytes returns bytes held by got and want
+// if at least one of them is not valid UTF-8.
+func binaryBytes(vx, vy reflect.Value) (got, want []byte, ok bool) {
+	got, gok := bytesValue(vx)
+	want, wok := bytesValue(vy)
+	if !gok || !wok || (utf8.Valid(got) && utf8.Valid(want)) {
+		return nil, nil, false
+	}
+	return got, want, true
+}
+
+// binaryPrefix returns the shortest prefix of p
+// pointing to binary data.
+func binaryPrefix(p cmp.Path) (cmp.Path, bool) {
+	for i := 1; i <= len(p); i++ {
+		if _, _, ok := binaryBytes(p.Index(i - 1).Values()); ok {
+			return p[:i], true
+		}
+	}
+	return nil, false
+}
+
+// hexSlot is a column of a hexdump diff holding
+// indices of got and want bytes, where -1 is a gap.
+type hexSlot struct {
+	got, want int
+}
+
+// alignBytes aligns got and want, so inserted and
+// deleted bytes do not shift the remaining ones.
+func alignBytes(got, want []byte) []hexSlot {
+	prefix := 0
+	for prefix < len(got) && prefix < len(want) && got[prefix] == want[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(got)-prefix && suffix < len(want)-prefix &&
+		got[len(got)-1-suffix] == want[len(want)-1-suffix] {
+		suffix++
+	}
+
+	var slots []hexSlot
+	for i := range prefix {
+		slots = append(slots, hexSlot{got: i, want: i})
+	}
+
+	gotm, wantm := got[prefix:len(got)-suffix], want[prefix:len(want)-suffix]
+	var edits []edit
+	ok := false
+	if len(gotm)+len(wantm) <= maxAlignBytes {
+		edits, ok = editScript(gotm, wantm)
+	}
+	if ok {
+		var dels, ins []int
+		flush := func() {
+			for i := range max(len(dels), len(ins)) {
+				s := hexSlot{got: -1, want: -1}
+				if i < len(dels) {
+					s.got = prefix + dels[i]
+				}
+				if i < len(ins) {
+					s.want = prefix + ins[i]
+				}
+				slots = append(slots, s)
+			}
+			dels, ins = nil, nil
+		}
+
+		for _, e := range edits {
+			switch e.op {
+			case '-':
+				dels = append(dels, e.a)
+			case '+':
+				ins = append(ins, e.b)
+			default:
+				flush()
+				slots = append(slots, hexSlot{got: prefix + e.a, want: prefix + e.b})
+			}
+		}
+		flush()
+	} else {
+		for i := range max(len(gotm), len(wantm)) {
+			s := hexSlot{got: -1, want: -1}
+			if i < len(gotm) {
+				s.got = prefix + i
+			}
+			if i < len(wantm) {
+				s.want = prefix + i
+			}
+			slots = append(slots, s)
+		}
+	}
+
+	for i := range suffix {
+		slots = append(slots, hexSlot{got: len(got) - suffix + i, want: len(want) - suffix + i})
+	}
+	return slots
+}
+
+// hexDiff returns a hexdump of got and want with offsets, hex bytes and
+// ASCII columns. Rows that differ are shown for got ("-") and want ("+"),
+// with differing bytes highlighted with colors or marked with "^" in a "?" line.
+// If n is not negative, only rows with bytes at most n bytes away from
+// a mismatch are shown.
+func hexDiff(got, want []byte, n int, color bool) string {
+	return hexDiffAt(got, want, 0, n, color)
+}
+
+// hexDiffAt returns the hexdump diff of data starting at the offset.
+func hexDiffAt(got, want []byte, offset, n int, color bool) string {
+	slots := alignBytes(got, want)
+	changed := make([]bool, len(slots))
+	for i, s := range slots {
+		changed[i] = s.got < 0 || s.want < 0 || got[s.got] != want[s.want]
+	}
+
+	visible := make([]bool, len(slots))
+	for i := range slots {
+		visible[i] = n < 0
+		if changed[i] {
+			for j := max(i-n, 0); j <= min(i+n, len(slots)-1); j++ {
+				visible[j] = true
+			}
+		}
+	}
+
+	var sb strings.Builder
+	gotOff, wantOff, hidden := offset, offset, 0
+	for start := 0; start < len(slots); start += hexRowBytes {
+		end := min(start+hexRowBytes, len(slots))
+		row := slots[start:end]
+		rowChanged := changed[start:end]
+
+		show, differ := false, false
+		for i := start; i < end; i++ {
+			show = show || visible[i]
+			differ = differ || changed[i]
+		}
+
+		if !show {
+			hidden += len(row)
+		} else {
+			if hidden > 0 {
+				fmt.Fprintf(&sb, "... %d equal bytes\n", hidden)
+				hidden = 0
+			}
+
+			if differ {
+				writeHexRow(&sb, '-', gotOff, row, got, rowChanged, color)
+				writeHexRow(&sb, '+', wantOff, row, want, rowChanged, color)
+				if !color {
+					sb.WriteString(hexMarker(row, rowChanged))
+					sb.WriteString("\n")
+				}
+			} else {
+				writeHexRow(&sb, ' ', gotOff, row, got, nil, color)
+			}
+		}
+
+		for _, s := range row {
+			if s.got >= 0 {
+				gotOff++
+			}
+			if s.want >= 0 {
+				wantOff++
+			}
+		}
+	}
+	if hidden > 0 {
+		fmt.Fprintf(&sb, "... %d equal bytes\n", hidden)
+	}
+	return sb.String()
+}
+
+// writeHexRow writes a row of bytes of one side at the offset.
+func writeHexRow(sb *strings.Builder, op byte, offset int, row []hexSlot, data []byte, changed []bool, color bool) {
+	// byteAt returns the index of the row byte of the side
+	byteAt := func(s hexSlot) int {
+		if op == '+' {
+			return s.want
+		}
+		return s.got
+	}
+
+	cell := func(i int, text string) string {
+		if color && changed != nil && changed[i] {
+			return colorReverse + text + colorReverseOff
+		}
+		return text
+	}
+
+	var hex, ascii strings.Builder
+	for i := range hexRowBytes {
+		if i == hexRowBytes/2 {
+			hex.WriteString(" ")
+		}
+		switch {
+		case i >= len(row):
+			hex.WriteString("   ")
+		case byteAt(row[i]) < 0:
+			hex.WriteString(cell(i, "  ") + " ")
+			ascii.WriteString(cell(i, " "))
+		default:
+			b := data[byteAt(row[i])]
+			hex.WriteString(cell(i, fmt.Sprintf("%02x", b)) + " ")
+			ascii.WriteString(cell(i, string(printableByte(b))))
+		}
+	}
+
+	line := fmt.Sprintf("%c%08x  %s |%s|", op, offset, hex.String(), ascii.String())
+	if color {
+		switch op {
+		case '-':
+			line = colorRed + line + colorReset
+		case '+':
+			line = colorGreen + line + colorReset
+		default:
+			line = colorDim + line + colorReset
+		}
+	}
+	sb.WriteString(line)
+	sb.WriteString("\n")
+}
+
+// hexMarker returns a line with "^" under changed bytes
+// in both the hex and the ASCII columns.
+func hexMarker(row []hexSlot, changed []bool) string {
+	var hex, ascii strings.Builder
+	for i := range row {
+		if i == hexRowBytes/2 {
+			hex.WriteString(" ")
+		}
+		if changed[i] {
+			hex.WriteString("^^ ")
+			ascii.WriteString("^")
+		} else {
+			hex.WriteString("   ")
+			ascii.WriteString(" ")
+		}
+	}
+	for i := len(row); i < hexRowBytes; i++ {
+		if i == hexRowBytes/2 {
+			hex.WriteString(" ")
+		}
+		hex.WriteString("   ")
+	}
+	return strings.TrimRight(fmt.Sprintf("?%8s  %s  %s", "", hex.String(), ascii.String()), " ")
+}
+
+// printableByte returns b if it is a printable ASCII character or '.' otherwise.
+func printableByte(b byte) byte {
+	if b < 0x20 || b > 0x7e {
+		return '.'
+	}
+	return b
+}
@@ -0,0 +1,74 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHexDiff(t *testing.T) {
+	Equal(t, hexDiff([]byte{0x00, 0x01, 0x02}, []byte{0x00, 0xff, 0x02}, -1, false), ""+
+		"-00000000  00 01 02                                          |...|\n"+
+		"+00000000  00 ff 02                                          |...|\n"+
+		"?             ^^                                               ^\n")
+
+	// inserted bytes do not shift the remaining ones
+	got := []byte("\xffabcdefghijklmnopqrstuvwxyz")
+	want := []byte("\xffabcXdefghijklmnopqrstuvwxyz")
+	Equal(t, hexDiff(got, want, -1, false), ""+
+		"-00000000  ff 61 62 63    64 65 66  67 68 69 6a 6b 6c 6d 6e  |.abc defghijklmn|\n"+
+		"+00000000  ff 61 62 63 58 64 65 66  67 68 69 6a 6b 6c 6d 6e  |.abcXdefghijklmn|\n"+
+		"?                      ^^                                         ^\n"+
+		" 0000000f  6f 70 71 72 73 74 75 76  77 78 79 7a              |opqrstuvwxyz|\n")
+
+	// rows far from mismatches are elided
+	got = append([]byte{0xff}, make([]byte, 64)...)
+	want = append([]byte{0xfe}, make([]byte, 64)...)
+	Equal(t, hexDiff(got, want, 2, false), ""+
+		"-00000000  ff 00 00 00 00 00 00 00  00 00 00 00 00 00 00 00  |................|\n"+
+		"+00000000  fe 00 00 00 00 00 00 00  00 00 00 00 00 00 00 00  |................|\n"+
+		"?          ^^                                                 ^\n"+
+		"... 49 equal bytes\n")
+
+	colored := hexDiff([]byte{0x01}, []byte{0x02}, -1, true)
+	True(t, strings.Contains(colored, colorRed+"-00000000  "+colorReverse+"01"+colorReverseOff))
+	False(t, strings.Contains(colored, "?"))
+}
+
+func TestAlignBytesLarge(t *testing.T) {
+	got := make([]byte, maxAlignBytes)
+	want := make([]byte, maxAlignBytes+1)
+	for i := range got {
+		got[i] = byte(i)
+		want[i+1] = byte(i + 1)
+	}
+
+	// large differing parts are compared byte by byte
+	slots := alignBytes(got, want)
+	Len(t, slots, maxAlignBytes+1)
+	Equal(t, slots[1], hexSlot{got: 1, want: 1})
+}
+
+func TestEqualBinary(t *testing.T) {
+	type Frame struct {
+		Magic [4]byte
+		Body  []byte
+	}
+
+	got := Frame{Magic: [4]byte{0xca, 0xfe, 0xba, 0xbe}, Body: []byte{0xff, 0x00}}
+	want := Frame{Magic: [4]byte{0xca, 0xfe, 0xba, 0xbf}, Body: []byte{0xff, 0x00}}
+
+	atb := &assertTB{TB: t}
+	Equal(atb, got, want)
+	atb.fail(t, "Frame.Magic:\n-00000000  ca fe ba be")
+	atb.fail(t, "+00000000  ca fe ba bf")
+	False(t, strings.Contains(atb.message, "Frame.Body"))
+
+	atb = &assertTB{TB: t}
+	Equal(atb, []byte{0xff, 0x01}, []byte{0xff, 0x02})
+	atb.fail(t, "diff:\n-00000000  ff 01")
+
+	// valid UTF-8 is not rendered as hexdump
+	atb = &assertTB{TB: t}
+	Equal(atb, []byte("ab"), []byte("ac"))
+	False(t, strings.Contains(atb.message, "00000000"))
+}
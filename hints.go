@@ -0,0 +1,139 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// relaxation is a candidate option that may make differing values equal.
+type relaxation struct {
+	message string
+	option  EqualOption
+
+	// enabled reports whether the option is already used.
+	enabled func(o *equaler) bool
+}
+
+// relaxations are grouped by the kind of difference they fix,
+// in each group from the strictest one. SkipEmptyFields is not
+// suggested, as it hides any difference of fields empty in want.
+var relaxations = [][]relaxation{
+	{{
+		message: "values are equal with IgnoreUnexported()",
+		option:  IgnoreUnexported(),
+		enabled: func(o *equaler) bool { return o.ignoreUnexported },
+	}},
+	{{
+		message: "values are equal with EquateEmpty()",
+		option:  EquateEmpty(),
+		enabled: func(o *equaler) bool { return o.equateEmpty },
+	}},
+	toleranceRelaxations(1e-12, 1e-9, 1e-6),
+	{
+		timeRelaxation("time.Microsecond", time.Microsecond),
+		timeRelaxation("time.Millisecond", time.Millisecond),
+		timeRelaxation("time.Second", time.Second),
+	},
+	{{
+		message: "values are equal ignoring slice order, see SortSlices()",
+		option:  func(o *equaler) { o.sortSlices = append(o.sortSlices, ignoreSliceOrder()) },
+		enabled: func(o *equaler) bool { return false },
+	}},
+}
+
+func toleranceRelaxations(margins ...float64) []relaxation {
+	var out []relaxation
+	for _, margin := range margins {
+		out = append(out, relaxation{
+			message: fmt.Sprintf("values are equal with Tolerance(%g)", margin),
+			option:  Tolerance(margin),
+			enabled: func(o *equaler) bool { return o.tolerance >= margin },
+		})
+	}
+	return out
+}
+
+// timeRelaxation returns a relaxation of times with the margin
+// written in Go syntax as name.
+func timeRelaxation(name string, margin time.Duration) relaxation {
+	return relaxation{
+		message: fmt.Sprintf("values are equal with EquateApproxTime(%s)", name),
+		option:  EquateApproxTime(margin),
+		enabled: func(o *equaler) bool { return o.timeMargin >= margin },
+	}
+}
+
+// hints returns messages about options that make got and want equal.
+// It compares the values again for every candidate, so it must be
+// called only when an assertion fails.
+func hints[V any](got, want V, opts ...EqualOption) string {
+	eq := newEqualer(opts...)
+
+	var sb strings.Builder
+	for _, group := range relaxations {
+		for _, r := range group {
+			if r.enabled(eq) {
+				continue
+			}
+			if relaxedEqual(got, want, append(slices.Clip(opts), r.option)) {
+				sb.WriteString("\nhint: ")
+				sb.WriteString(r.message)
+				break
+			}
+		}
+	}
+	return sb.String()
+}
+
+// relaxedEqual compares got and want, treating panics
+// of options that do not apply to the values as differences.
+func relaxedEqual[V any](got, want V, opts []EqualOption) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	return equal(got, want, opts...)
+}
+
+// ignoreSliceOrder returns an [cmp.Option] that sorts slices
+// of any type by the Go syntax of their elements.
+func ignoreSliceOrder() cmp.Option {
+	return cmp.FilterValues(func(x, y any) bool {
+		vx, vy := reflect.ValueOf(x), reflect.ValueOf(y)
+		return vx.IsValid() && vy.IsValid() && vx.Type() == vy.Type() &&
+			vx.Kind() == reflect.Slice && (!isSortedByText(vx) || !isSortedByText(vy))
+	}, cmp.Transformer("assert.sortSlice", func(x any) any {
+		v := reflect.ValueOf(x)
+		keys := sliceTexts(v)
+		idx := make([]int, v.Len())
+		for i := range idx {
+			idx[i] = i
+		}
+		slices.SortStableFunc(idx, func(a, b int) int { return strings.Compare(keys[a], keys[b]) })
+
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i, j := range idx {
+			out.Index(i).Set(v.Index(j))
+		}
+		return out.Interface()
+	}))
+}
+
+func sliceTexts(v reflect.Value) []string {
+	eq := newEqualer()
+	keys := make([]string, v.Len())
+	for i := range keys {
+		keys[i] = eq.sprintLine(v.Index(i), "", "", nil)
+	}
+	return keys
+}
+
+func isSortedByText(v reflect.Value) bool {
+	return slices.IsSorted(sliceTexts(v))
+}
@@ -0,0 +1,71 @@
+package assert
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEqualHints(t *testing.T) {
+	type Event struct {
+		Name  string
+		At    time.Time
+		Tags  []string
+		Score float64
+		seq   int
+	}
+
+	a, b := 0.1, 0.2
+	now := time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)
+	base := Event{Name: "a", At: now, Tags: []string{"x", "y"}, Score: 0.3}
+
+	tests := []struct {
+		name string
+		got  Event
+		opts []EqualOption
+		hint string
+	}{
+		{
+			name: "unexported",
+			got:  Event{Name: "a", At: now, Tags: []string{"x", "y"}, Score: 0.3, seq: 1},
+			hint: "hint: values are equal with IgnoreUnexported()",
+		},
+		{
+			name: "float noise",
+			got:  Event{Name: "a", At: now, Tags: []string{"x", "y"}, Score: a + b},
+			hint: "hint: values are equal with Tolerance(1e-12)",
+		},
+		{
+			name: "time precision",
+			got:  Event{Name: "a", At: now.Add(300 * time.Microsecond), Tags: []string{"x", "y"}, Score: 0.3},
+			hint: "hint: values are equal with EquateApproxTime(time.Millisecond)",
+		},
+		{
+			name: "slice order",
+			got:  Event{Name: "a", At: now, Tags: []string{"y", "x"}, Score: 0.3},
+			hint: "hint: values are equal ignoring slice order, see SortSlices()",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atb := &assertTB{TB: t}
+			Equal(atb, tt.got, base, tt.opts...)
+			atb.fail(t, tt.hint)
+		})
+	}
+
+	// options suggested by hints make values equal
+	Equal(t, Event{Tags: []string{}}, Event{}, EquateEmpty())
+	Equal(t, []string{"b", "a"}, []string{"a", "b"}, SortSlices(func(a, b string) bool { return a < b }))
+	Equal(t, now, now.Add(time.Millisecond), EquateApproxTime(time.Second))
+
+	atb := &assertTB{TB: t}
+	Equal(atb, Event{Tags: []string{}}, Event{})
+	atb.fail(t, "hint: values are equal with EquateEmpty()")
+
+	// no hints when nothing helps
+	Equal(t, hints(Event{Name: "a"}, Event{Name: "b"}), "")
+
+	// no hints hiding fields missing in want
+	Equal(t, hints(Event{Name: "a", Score: 0.5, Tags: []string{"x"}}, Event{Name: "a"}), "")
+}
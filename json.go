@@ -0,0 +1,364 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// JSONEq checks if got and want are semantically equal JSON documents.
+//
+// Documents are compared after decoding, so key order, whitespace
+// and number formatting (e.g. 1, 1.0 and 1e0) do not matter.
+//
+// The following options are supported:
+//
+//   - [SkipEmptyFields] ignores object members that are empty in want
+//     (null, "", 0, false, [] or {}) or missing from want.
+//   - [SkipZeroFields] ignores object members that are null, "", 0 or false
+//     in want or missing from want.
+//   - [SkipFieldNames] ignores members by path, e.g. "items[*].id".
+//     A path consists of dot-delimited member names, where "*" matches
+//     any member name, and array indices, where "[*]" matches any index.
+//   - [Tolerance] treats numbers within the margin as equal.
+func JSONEq[D ~string | ~[]byte](t testing.TB, got D, want D, opts ...EqualOption) bool {
+	t.Helper()
+	return failed(t, 1, CheckJSONEq(got, want, opts...))
+}
+
+// CheckJSONEq returns a [*Failure] if got and want are not
+// semantically equal JSON documents, see [JSONEq].
+func CheckJSONEq[D ~string | ~[]byte](got D, want D, opts ...EqualOption) error {
+	gotv, err := decodeJSON([]byte(got))
+	if err != nil {
+		return &Failure{Message: fmt.Sprintf("invalid got JSON: %v", err), Got: got}
+	}
+
+	wantv, err := decodeJSON([]byte(want))
+	if err != nil {
+		return &Failure{Message: fmt.Sprintf("invalid want JSON: %v", err), Want: want}
+	}
+
+	jc := newJSONComparer(newEqualer(opts...))
+	jc.compare(jsonPath{}, gotv, wantv, true, true)
+	if len(jc.diffs) > 0 {
+		return &Failure{
+			Message: "expected equal JSON",
+			Got:     got,
+			Want:    want,
+			Diff:    "diff:\n" + strings.Join(jc.diffs, "\n"),
+		}
+	}
+	return nil
+}
+
+// decodeJSON decodes a single JSON document.
+// Numbers are decoded as [json.Number] to keep their precision.
+func decodeJSON(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after top-level value")
+	}
+	return v, nil
+}
+
+// jsonPath is a location in a JSON document.
+// Each element is either a member name or an array index in brackets.
+type jsonPath []string
+
+func (p jsonPath) key(name string) jsonPath {
+	return append(p[:len(p):len(p)], name)
+}
+
+func (p jsonPath) index(i int) jsonPath {
+	return append(p[:len(p):len(p)], "["+strconv.Itoa(i)+"]")
+}
+
+// String returns the path in the JSONPath notation, e.g. $.items[0].id.
+func (p jsonPath) String() string {
+	var sb strings.Builder
+	sb.WriteString("$")
+	for _, s := range p {
+		switch {
+		case strings.HasPrefix(s, "["):
+			sb.WriteString(s)
+		case isJSONIdent(s):
+			sb.WriteString(".")
+			sb.WriteString(s)
+		default:
+			sb.WriteString("[")
+			sb.WriteString(strconv.Quote(s))
+			sb.WriteString("]")
+		}
+	}
+	return sb.String()
+}
+
+func isJSONIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r == '_' || r == '$':
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// parseJSONPattern parses a skip pattern like "items[*].id".
+func parseJSONPattern(s string) jsonPath {
+	s = strings.TrimPrefix(s, "$")
+	s = strings.TrimPrefix(s, ".")
+
+	var p jsonPath
+	for _, part := range strings.Split(s, ".") {
+		if i := strings.IndexByte(part, '['); i >= 0 {
+			if i > 0 {
+				p = append(p, part[:i])
+			}
+			for _, idx := range strings.SplitAfter(part[i:], "]") {
+				if idx != "" {
+					p = append(p, idx)
+				}
+			}
+			continue
+		}
+		p = append(p, part)
+	}
+	return p
+}
+
+// match reports whether path p matches pattern.
+func (pattern jsonPath) match(p jsonPath) bool {
+	if len(pattern) != len(p) {
+		return false
+	}
+	for i := range pattern {
+		switch {
+		case pattern[i] == p[i]:
+		case pattern[i] == "[*]" && strings.HasPrefix(p[i], "["):
+		case pattern[i] == "*" && !strings.HasPrefix(p[i], "["):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+type jsonComparer struct {
+	eq    *equaler
+	skip  []jsonPath
+	diffs []string
+}
+
+func newJSONComparer(eq *equaler) *jsonComparer {
+	jc := &jsonComparer{eq: eq}
+	for _, name := range eq.skipFieldNames {
+		jc.skip = append(jc.skip, parseJSONPattern(name))
+	}
+	return jc
+}
+
+func (jc *jsonComparer) skipped(p jsonPath) bool {
+	for _, pattern := range jc.skip {
+		if pattern.match(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// compare records differences between got and want at path p.
+// hasGot and hasWant report whether the values are present at all.
+func (jc *jsonComparer) compare(p jsonPath, got, want any, hasGot, hasWant bool) {
+	if jc.skipped(p) {
+		return
+	}
+
+	if !hasGot || !hasWant {
+		jc.report(p, got, want, hasGot, hasWant)
+		return
+	}
+
+	switch w := want.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			jc.report(p, got, want, true, true)
+			return
+		}
+
+		keys := make([]string, 0, len(g)+len(w))
+		for k := range g {
+			keys = append(keys, k)
+		}
+		for k := range w {
+			if _, ok := g[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			gv, gok := g[k]
+			wv, wok := w[k]
+			if (jc.eq.skipEmptyFields && (!wok || isEmptyJSON(wv))) ||
+				(jc.eq.skipZeroFields && (!wok || isZeroJSON(wv))) {
+				continue
+			}
+			jc.compare(p.key(k), gv, wv, gok, wok)
+		}
+
+	case []any:
+		g, ok := got.([]any)
+		if !ok {
+			jc.report(p, got, want, true, true)
+			return
+		}
+
+		for i := 0; i < max(len(g), len(w)); i++ {
+			var gv, wv any
+			if i < len(g) {
+				gv = g[i]
+			}
+			if i < len(w) {
+				wv = w[i]
+			}
+			jc.compare(p.index(i), gv, wv, i < len(g), i < len(w))
+		}
+
+	case json.Number:
+		g, ok := got.(json.Number)
+		if !ok || !jc.equalNumber(g, w) {
+			jc.report(p, got, want, true, true)
+		}
+
+	default:
+		// null, bool and string are comparable
+		if got != want {
+			jc.report(p, got, want, true, true)
+		}
+	}
+}
+
+func (jc *jsonComparer) report(p jsonPath, got, want any, hasGot, hasWant bool) {
+	gs, ws := "<missing>", "<missing>"
+	if hasGot {
+		gs = jsonSnippet(got)
+	}
+	if hasWant {
+		ws = jsonSnippet(want)
+	}
+	jc.diffs = append(jc.diffs, fmt.Sprintf("  %s: got %s, want %s", p, gs, ws))
+}
+
+// equalJSON reports whether decoded JSON values are equal.
+func equalJSON(a, b any) bool {
+	jc := newJSONComparer(newEqualer())
+	jc.compare(jsonPath{}, a, b, true, true)
+	return len(jc.diffs) == 0
+}
+
+// equalNumber compares numbers using the configured tolerance.
+func (jc *jsonComparer) equalNumber(a, b json.Number) bool {
+	if equalJSONNumber(a, b) {
+		return true
+	}
+	return jc.eq.tolerance > 0 && withinTolerance(string(a), string(b), jc.eq.tolerance)
+}
+
+// withinTolerance reports whether a and b are numbers
+// with absolute difference of at most margin.
+func withinTolerance(a, b string, margin float64) bool {
+	fa, err := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	if err != nil {
+		return false
+	}
+	fb, err := strconv.ParseFloat(strings.TrimSpace(b), 64)
+	if err != nil {
+		return false
+	}
+	return math.Abs(fa-fb) <= margin
+}
+
+// equalJSONNumber compares numbers by value, regardless of their formatting.
+func equalJSONNumber(a, b json.Number) bool {
+	if a == b {
+		return true
+	}
+
+	ra, ok := new(big.Rat).SetString(string(a))
+	if !ok {
+		return false
+	}
+	rb, ok := new(big.Rat).SetString(string(b))
+	if !ok {
+		return false
+	}
+	return ra.Cmp(rb) == 0
+}
+
+// jsonSnippetLimit is the maximum length of a JSON value in failure messages.
+const jsonSnippetLimit = 64
+
+// jsonSnippet returns a compact, possibly truncated, JSON encoding of v.
+func jsonSnippet(v any) string {
+	return jsonTruncate(v, jsonSnippetLimit)
+}
+
+// jsonTruncate returns a compact JSON encoding of v
+// truncated to at most limit bytes.
+func jsonTruncate(v any, limit int) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	if len(data) > limit {
+		return string(data[:limit]) + "..."
+	}
+	return string(data)
+}
+
+func isZeroJSON(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case string:
+		return v == ""
+	case json.Number:
+		return equalJSONNumber(v, "0")
+	default:
+		return false
+	}
+}
+
+func isEmptyJSON(v any) bool {
+	switch v := v.(type) {
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	default:
+		return isZeroJSON(v)
+	}
+}
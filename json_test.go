@@ -0,0 +1,99 @@
+package assert
+
+import (
+	"testing"
+)
+
+func TestJSONEq(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+		opts []EqualOption
+		fail string
+	}{
+		{
+			name: "key order and whitespace",
+			got:  `{"a": 1, "b": [true, null]}`,
+			want: `{"b":[true,null],"a":1}`,
+		},
+		{
+			name: "number formatting",
+			got:  `{"a": 1.0, "b": 1e2, "c": 12345678901234567890}`,
+			want: `{"a": 1, "b": 100, "c": 12345678901234567890}`,
+		},
+		{
+			name: "different value",
+			got:  `{"items": [{"id": 1, "price": 12.5}]}`,
+			want: `{"items": [{"id": 1, "price": 13}]}`,
+			fail: "$.items[0].price: got 12.5, want 13",
+		},
+		{
+			name: "missing member",
+			got:  `{"a": 1}`,
+			want: `{"a": 1, "b": "x"}`,
+			fail: `$.b: got <missing>, want "x"`,
+		},
+		{
+			name: "extra element",
+			got:  `[1, 2]`,
+			want: `[1]`,
+			fail: "$[1]: got 2, want <missing>",
+		},
+		{
+			name: "different type",
+			got:  `{"a": "1"}`,
+			want: `{"a": 1}`,
+			fail: `$.a: got "1", want 1`,
+		},
+		{
+			name: "non identifier key",
+			got:  `{"a b": 1}`,
+			want: `{"a b": 2}`,
+			fail: `$["a b"]: got 1, want 2`,
+		},
+		{
+			name: "skip field names",
+			got:  `{"items": [{"id": 1, "name": "a"}, {"id": 2, "name": "b"}], "at": "now"}`,
+			want: `{"items": [{"id": 3, "name": "a"}, {"id": 4, "name": "b"}]}`,
+			opts: []EqualOption{SkipFieldNames("items[*].id", "$.at")},
+		},
+		{
+			name: "skip empty fields",
+			got:  `{"id": 1, "name": "a", "tags": ["x"]}`,
+			want: `{"name": "a", "tags": []}`,
+			opts: []EqualOption{SkipEmptyFields()},
+		},
+		{
+			name: "skip zero fields",
+			got:  `{"id": 1, "name": "a", "tags": ["x"]}`,
+			want: `{"id": 0, "name": "a", "tags": []}`,
+			opts: []EqualOption{SkipZeroFields()},
+			fail: `$.tags[0]: got "x", want <missing>`,
+		},
+		{
+			name: "invalid got",
+			got:  `{`,
+			want: `{}`,
+			fail: "invalid got JSON",
+		},
+		{
+			name: "invalid want",
+			got:  `{}`,
+			want: `{} {}`,
+			fail: "invalid want JSON",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atb := &assertTB{TB: t}
+			JSONEq(atb, tt.got, tt.want, tt.opts...)
+			atb.check(t, tt.fail)
+		})
+	}
+
+	atb := &assertTB{TB: t}
+	JSONEq(atb, []byte(`{"a":1}`), []byte(`{"a":1}`))
+	atb.pass(t)
+}
@@ -0,0 +1,454 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// JSONPath checks if the value selected by path in the JSON document
+// is equal to want. See [Equal] for rules used to determine equality.
+//
+// The selected value is decoded into the type of want, so a number
+// can be compared with any Go numeric type, an object with a struct
+// or a map and so on. If the path may select multiple values
+// (it contains a wildcard or a filter), all of them are decoded
+// into want as an array.
+//
+// The path uses a subset of the JSONPath syntax:
+//
+//	$                  the root value
+//	.name, ['name']    object member
+//	[0], [-1]          array element, negative indices count from the end
+//	.*, [*]            all members or elements
+//	[?(@.a.b == 1)]    elements with a member equal (==) or not equal (!=) to a JSON literal
+//
+// JSONPath panics if the path is invalid.
+func JSONPath[D ~string | ~[]byte, V any](t testing.TB, doc D, path string, want V, opts ...EqualOption) bool {
+	t.Helper()
+	return failed(t, 1, checkJSONPath(t, doc, path, want, opts...))
+}
+
+// CheckJSONPath returns a [*Failure] if the value selected by path
+// in the JSON document is not equal to want, see [JSONPath].
+func CheckJSONPath[D ~string | ~[]byte, V any](doc D, path string, want V, opts ...EqualOption) error {
+	return checkJSONPath(nil, doc, path, want, opts...)
+}
+
+func checkJSONPath[D ~string | ~[]byte, V any](t testing.TB, doc D, path string, want V, opts ...EqualOption) error {
+	r, err := evalJSONPath(doc, path)
+	if err != nil {
+		return err
+	}
+
+	var value any
+	switch {
+	case !r.definite:
+		values := make([]any, 0, len(r.matches))
+		for _, m := range r.matches {
+			values = append(values, m.value)
+		}
+		value = values
+	case len(r.matches) == 1:
+		value = r.matches[0].value
+	default:
+		return &Failure{Message: fmt.Sprintf("no match for %s\n%s", path, r.near()), Want: want}
+	}
+
+	var got V
+	data, err := json.Marshal(value)
+	if err == nil {
+		err = json.Unmarshal(data, &got)
+	}
+	if err != nil {
+		return &Failure{
+			Message: fmt.Sprintf("cannot decode %s into %T: %v\n%s", path, want, err, r.near()),
+			Got:     value,
+			Want:    want,
+		}
+	}
+
+	if !equal(got, want, opts...) {
+		return &Failure{
+			Message: fmt.Sprintf("expected equal at %s\n%s", path, r.near()),
+			Got:     got,
+			Want:    want,
+			Diff:    diffValue(t, got, want, opts...),
+		}
+	}
+	return nil
+}
+
+// JSONPathExists checks if path selects at least one value in the JSON document.
+// See [JSONPath] for the path syntax.
+func JSONPathExists[D ~string | ~[]byte](t testing.TB, doc D, path string) bool {
+	t.Helper()
+	return failed(t, 1, CheckJSONPathExists(doc, path))
+}
+
+// CheckJSONPathExists returns a [*Failure] if path selects
+// no values in the JSON document, see [JSONPathExists].
+func CheckJSONPathExists[D ~string | ~[]byte](doc D, path string) error {
+	r, err := evalJSONPath(doc, path)
+	if err != nil {
+		return err
+	}
+	if len(r.matches) == 0 {
+		return &Failure{Message: fmt.Sprintf("no match for %s\n%s", path, r.near())}
+	}
+	return nil
+}
+
+// JSONPathNotExists checks if path selects no values in the JSON document.
+// See [JSONPath] for the path syntax.
+func JSONPathNotExists[D ~string | ~[]byte](t testing.TB, doc D, path string) bool {
+	t.Helper()
+	return failed(t, 1, CheckJSONPathNotExists(doc, path))
+}
+
+// CheckJSONPathNotExists returns a [*Failure] if path selects
+// any values in the JSON document, see [JSONPathNotExists].
+func CheckJSONPathNotExists[D ~string | ~[]byte](doc D, path string) error {
+	r, err := evalJSONPath(doc, path)
+	if err != nil {
+		return err
+	}
+	if len(r.matches) > 0 {
+		return &Failure{Message: fmt.Sprintf("expected no match for %s, got %d\n%s", path, len(r.matches), r.found())}
+	}
+	return nil
+}
+
+// JSONPathCount checks if path selects exactly want values in the JSON document.
+// See [JSONPath] for the path syntax.
+func JSONPathCount[D ~string | ~[]byte](t testing.TB, doc D, path string, want int) bool {
+	t.Helper()
+	return failed(t, 1, CheckJSONPathCount(doc, path, want))
+}
+
+// CheckJSONPathCount returns a [*Failure] if path does not select
+// exactly want values in the JSON document, see [JSONPathCount].
+func CheckJSONPathCount[D ~string | ~[]byte](doc D, path string, want int) error {
+	r, err := evalJSONPath(doc, path)
+	if err != nil {
+		return err
+	}
+	if len(r.matches) != want {
+		return &Failure{
+			Message: fmt.Sprintf("expected %d matches for %s, got %d\n%s", want, path, len(r.matches), r.near()),
+			Got:     len(r.matches),
+			Want:    want,
+		}
+	}
+	return nil
+}
+
+// jsonFragmentLimit is the maximum length of a surrounding
+// JSON fragment in failure messages.
+const jsonFragmentLimit = 256
+
+// jsonMatch is a value selected by a JSON path.
+type jsonMatch struct {
+	path  jsonPath
+	value any
+}
+
+// jsonPathResult is the result of a JSON path evaluation.
+type jsonPathResult struct {
+	// matches are the selected values.
+	matches []jsonMatch
+
+	// context is the value surrounding the matches, or the last value
+	// successfully selected when the path does not match anything.
+	context jsonMatch
+
+	// definite reports whether the path selects at most one value.
+	definite bool
+}
+
+// near returns the surrounding JSON fragment for failure messages.
+func (r jsonPathResult) near() string {
+	return fmt.Sprintf("near %s: %s", r.context.path, jsonTruncate(r.context.value, jsonFragmentLimit))
+}
+
+// found returns the selected values for failure messages.
+func (r jsonPathResult) found() string {
+	lines := make([]string, 0, len(r.matches))
+	for _, m := range r.matches {
+		lines = append(lines, fmt.Sprintf("  %s: %s", m.path, jsonSnippet(m.value)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// evalJSONPath selects values by path in the JSON document.
+// It returns a [*Failure] if the document is invalid.
+func evalJSONPath[D ~string | ~[]byte](doc D, path string) (jsonPathResult, error) {
+	steps, err := parseJSONPath(path)
+	if err != nil {
+		panic(fmt.Sprintf("invalid JSON path %q: %v", path, err))
+	}
+
+	v, err := decodeJSON([]byte(doc))
+	if err != nil {
+		return jsonPathResult{}, &Failure{Message: fmt.Sprintf("invalid JSON: %v", err), Got: doc}
+	}
+
+	r := jsonPathResult{
+		matches:  []jsonMatch{{path: jsonPath{}, value: v}},
+		definite: true,
+	}
+	// definite depends on the path, not on how far it matched
+	for _, step := range steps {
+		r.definite = r.definite && (step.kind == jsonStepMember || step.kind == jsonStepIndex)
+	}
+
+	for _, step := range steps {
+		next := []jsonMatch{}
+		for _, m := range r.matches {
+			next = append(next, step.apply(m)...)
+		}
+
+		if len(r.matches) > 0 {
+			r.context = r.matches[0]
+		}
+		r.matches = next
+		if len(next) == 0 {
+			break
+		}
+	}
+	return r, nil
+}
+
+type jsonStepKind int
+
+const (
+	jsonStepMember jsonStepKind = iota
+	jsonStepIndex
+	jsonStepWildcard
+	jsonStepFilter
+)
+
+// jsonPathStep is a single selector of a JSON path.
+type jsonPathStep struct {
+	kind  jsonStepKind
+	name  string // member name
+	index int    // array index
+
+	// filter selects elements where the value at the member path
+	// is equal (or not equal, if negate is set) to the literal.
+	filter  []string
+	literal any
+	negate  bool
+}
+
+// apply returns the values selected by the step from m.
+func (s jsonPathStep) apply(m jsonMatch) []jsonMatch {
+	switch s.kind {
+	case jsonStepMember:
+		if obj, ok := m.value.(map[string]any); ok {
+			if v, ok := obj[s.name]; ok {
+				return []jsonMatch{{path: m.path.key(s.name), value: v}}
+			}
+		}
+
+	case jsonStepIndex:
+		if arr, ok := m.value.([]any); ok {
+			i := s.index
+			if i < 0 {
+				i += len(arr)
+			}
+			if i >= 0 && i < len(arr) {
+				return []jsonMatch{{path: m.path.index(i), value: arr[i]}}
+			}
+		}
+
+	case jsonStepWildcard, jsonStepFilter:
+		var children []jsonMatch
+		switch v := m.value.(type) {
+		case []any:
+			for i, e := range v {
+				children = append(children, jsonMatch{path: m.path.index(i), value: e})
+			}
+		case map[string]any:
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				children = append(children, jsonMatch{path: m.path.key(k), value: v[k]})
+			}
+		}
+
+		if s.kind == jsonStepWildcard {
+			return children
+		}
+
+		var out []jsonMatch
+		for _, c := range children {
+			if s.match(c.value) {
+				out = append(out, c)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// match reports whether v passes the filter.
+func (s jsonPathStep) match(v any) bool {
+	for _, name := range s.filter {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return false
+		}
+		if v, ok = obj[name]; !ok {
+			return false
+		}
+	}
+
+	return equalJSON(v, s.literal) != s.negate
+}
+
+// parseJSONPath parses a JSON path into a list of steps.
+func parseJSONPath(path string) ([]jsonPathStep, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("path must start with $")
+	}
+
+	var steps []jsonPathStep
+	s := path[1:]
+	for s != "" {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+			name := s[:end]
+			s = s[end:]
+			switch name {
+			case "":
+				return nil, fmt.Errorf("empty member name")
+			case "*":
+				steps = append(steps, jsonPathStep{kind: jsonStepWildcard})
+			default:
+				steps = append(steps, jsonPathStep{kind: jsonStepMember, name: name})
+			}
+
+		case '[':
+			end := closingBracket(s)
+			if end < 0 {
+				return nil, fmt.Errorf("missing ]")
+			}
+			step, err := parseJSONBracket(s[1:end])
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, step)
+			s = s[end+1:]
+
+		default:
+			return nil, fmt.Errorf("unexpected %q", s[0])
+		}
+	}
+	return steps, nil
+}
+
+// closingBracket returns the index of "]" closing the bracket
+// at the start of s, skipping over quoted strings.
+func closingBracket(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '\'' || c == '"':
+			quote = c
+		case c == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+// parseJSONBracket parses the content of a bracket selector.
+func parseJSONBracket(s string) (jsonPathStep, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "*":
+		return jsonPathStep{kind: jsonStepWildcard}, nil
+
+	case strings.HasPrefix(s, "'") || strings.HasPrefix(s, `"`):
+		name, err := unquoteJSONPath(s)
+		if err != nil {
+			return jsonPathStep{}, err
+		}
+		return jsonPathStep{kind: jsonStepMember, name: name}, nil
+
+	case strings.HasPrefix(s, "?(") && strings.HasSuffix(s, ")"):
+		return parseJSONFilter(s[2 : len(s)-1])
+
+	default:
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return jsonPathStep{}, fmt.Errorf("invalid index %q", s)
+		}
+		return jsonPathStep{kind: jsonStepIndex, index: i}, nil
+	}
+}
+
+// parseJSONFilter parses a filter expression like "@.a.b == 'x'".
+func parseJSONFilter(s string) (jsonPathStep, error) {
+	step := jsonPathStep{kind: jsonStepFilter}
+
+	i := strings.Index(s, "==")
+	if j := strings.Index(s, "!="); i < 0 || (j >= 0 && j < i) {
+		i, step.negate = j, true
+	}
+	if i < 0 {
+		return step, fmt.Errorf("filter %q must use == or !=", s)
+	}
+
+	left, right := strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+2:])
+	if !strings.HasPrefix(left, "@") {
+		return step, fmt.Errorf("filter %q must start with @", s)
+	}
+	if left = strings.TrimPrefix(left[1:], "."); left != "" {
+		step.filter = strings.Split(left, ".")
+	}
+
+	if strings.HasPrefix(right, "'") {
+		lit, err := unquoteJSONPath(right)
+		if err != nil {
+			return step, err
+		}
+		step.literal = lit
+		return step, nil
+	}
+
+	lit, err := decodeJSON([]byte(right))
+	if err != nil {
+		return step, fmt.Errorf("invalid literal %q: %v", right, err)
+	}
+	step.literal = lit
+	return step, nil
+}
+
+// unquoteJSONPath unquotes a single or double quoted string.
+func unquoteJSONPath(s string) (string, error) {
+	if len(s) < 2 || s[0] != s[len(s)-1] {
+		return "", fmt.Errorf("invalid string %s", s)
+	}
+	if s[0] == '\'' {
+		s = `"` + strings.ReplaceAll(s[1:len(s)-1], `"`, `\"`) + `"`
+	}
+	return strconv.Unquote(s)
+}
@@ -0,0 +1,117 @@
+package assert
+
+import (
+	"testing"
+)
+
+const jsonPathDoc = `{
+	"store": "main",
+	"items": [
+		{"id": 1, "name": "apple", "price": 12.5, "tags": ["fruit"]},
+		{"id": 2, "name": "bread", "price": 3, "meta": {"vegan": true}},
+		{"id": 3, "name": "milk", "price": 2.25}
+	]
+}`
+
+func TestJSONPath(t *testing.T) {
+	type item struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	atb := &assertTB{TB: t}
+	JSONPath(atb, jsonPathDoc, "$.items[0].price", 12.5)
+	atb.pass(t)
+
+	atb = &assertTB{TB: t}
+	JSONPath(atb, jsonPathDoc, "$['store']", "main")
+	atb.pass(t)
+
+	atb = &assertTB{TB: t}
+	JSONPath(atb, jsonPathDoc, "$.items[-1].name", "milk")
+	atb.pass(t)
+
+	atb = &assertTB{TB: t}
+	JSONPath(atb, jsonPathDoc, "$.items[*].id", []int{1, 2, 3})
+	atb.pass(t)
+
+	atb = &assertTB{TB: t}
+	JSONPath(atb, jsonPathDoc, "$.items[?(@.meta.vegan == true)].name", []string{"bread"})
+	atb.pass(t)
+
+	atb = &assertTB{TB: t}
+	JSONPath(atb, jsonPathDoc, "$.items[?(@.name != 'bread')]", []item{{1, "apple"}, {3, "milk"}})
+	atb.pass(t)
+
+	atb = &assertTB{TB: t}
+	JSONPath(atb, jsonPathDoc, "$.items[1]", item{ID: 2, Name: "bread"})
+	atb.pass(t)
+
+	atb = &assertTB{TB: t}
+	JSONPath(atb, jsonPathDoc, "$.missing[*].id", []int{})
+	atb.pass(t)
+
+	atb = &assertTB{TB: t}
+	JSONPath(atb, jsonPathDoc, "$.items[0].price", 13.0)
+	atb.fail(t, `expected equal at $.items[0].price
+near $.items[0]: {"id":1,"name":"apple","price":12.5,"tags":["fruit"]}`)
+
+	atb = &assertTB{TB: t}
+	JSONPath(atb, jsonPathDoc, "$.items[1].tags[0]", "fruit")
+	atb.fail(t, `no match for $.items[1].tags[0]
+near $.items[1]: {"id":2,"meta":{"vegan":true},"name":"bread","price":3}`)
+
+	atb = &assertTB{TB: t}
+	JSONPath(atb, jsonPathDoc, "$.items[0].name", 1)
+	atb.fail(t, "cannot decode $.items[0].name into int")
+
+	atb = &assertTB{TB: t}
+	JSONPath(atb, "{", "$.a", 1)
+	atb.fail(t, "invalid JSON")
+
+	Panic(t, func() {
+		JSONPath(t, jsonPathDoc, "items", 1)
+	})
+	Panic(t, func() {
+		JSONPath(t, jsonPathDoc, "$.items[0", 1)
+	})
+	Panic(t, func() {
+		JSONPath(t, jsonPathDoc, "$.items[?(@.id > 1)]", 1)
+	})
+}
+
+func TestJSONPathExists(t *testing.T) {
+	atb := &assertTB{TB: t}
+	JSONPathExists(atb, jsonPathDoc, "$.items[1].meta.vegan")
+	atb.pass(t)
+
+	atb = &assertTB{TB: t}
+	JSONPathExists(atb, jsonPathDoc, "$.items[2].meta")
+	atb.fail(t, `no match for $.items[2].meta
+near $.items[2]: {"id":3,"name":"milk","price":2.25}`)
+}
+
+func TestJSONPathNotExists(t *testing.T) {
+	atb := &assertTB{TB: t}
+	JSONPathNotExists(atb, jsonPathDoc, "$.items[2].meta")
+	atb.pass(t)
+
+	atb = &assertTB{TB: t}
+	JSONPathNotExists(atb, jsonPathDoc, "$.items[*].meta")
+	atb.fail(t, `expected no match for $.items[*].meta, got 1
+  $.items[1].meta: {"vegan":true}`)
+}
+
+func TestJSONPathCount(t *testing.T) {
+	atb := &assertTB{TB: t}
+	JSONPathCount(atb, jsonPathDoc, "$.items[*]", 3)
+	atb.pass(t)
+
+	atb = &assertTB{TB: t}
+	JSONPathCount(atb, jsonPathDoc, "$.*", 2)
+	atb.pass(t)
+
+	atb = &assertTB{TB: t}
+	JSONPathCount(atb, jsonPathDoc, "$.items[?(@.price == 3.0)]", 2)
+	atb.fail(t, "expected 2 matches for $.items[?(@.price == 3.0)], got 1")
+}
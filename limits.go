@@ -0,0 +1,284 @@
+package assert
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxDiffLines returns an EqualOption that limits diffs to n lines.
+// Zero means no limit.
+//
+// The limit can be set for all assertions with
+// the ASSERT_MAX_DIFF_LINES environment variable.
+func MaxDiffLines(n int) EqualOption {
+	if n < 0 {
+		panic("MaxDiffLines: negative limit")
+	}
+	return func(o *equaler) {
+		o.maxDiffLines = n
+	}
+}
+
+// MaxDiffPaths returns an EqualOption that limits diffs to the first
+// n differing values, e.g. fields or slice elements. Zero means no limit.
+// Diffs of values with more differences are rendered as line-based diffs
+// of the values in Go syntax, where other differing values are written
+// as "<not shown>".
+//
+// The limit can be set for all assertions with
+// the ASSERT_MAX_DIFF_PATHS environment variable.
+func MaxDiffPaths(n int) EqualOption {
+	if n < 0 {
+		panic("MaxDiffPaths: negative limit")
+	}
+	return func(o *equaler) {
+		o.maxDiffPaths = n
+	}
+}
+
+// MaxValueBytes returns an EqualOption that limits values in diffs to
+// n bytes. Multi-line texts and binary data are cut to n bytes around
+// their first difference before they are diffed, and lines of other diffs,
+// which usually hold a single value, are cut to n bytes. Zero means no limit.
+//
+// The limit can be set for all assertions with
+// the ASSERT_MAX_VALUE_BYTES environment variable.
+func MaxValueBytes(n int) EqualOption {
+	if n < 0 {
+		panic("MaxValueBytes: negative limit")
+	}
+	return func(o *equaler) {
+		o.maxValueBytes = n
+	}
+}
+
+// diffLimits are limits of the diff size, where zero means no limit.
+type diffLimits struct {
+	lines, paths, valueBytes int
+}
+
+// limits returns limits set with options or environment variables.
+func (o *equaler) limits() diffLimits {
+	limit := func(n int, env string) int {
+		if n > 0 {
+			return n
+		}
+		if n, err := strconv.Atoi(os.Getenv(env)); err == nil && n > 0 {
+			return n
+		}
+		return 0
+	}
+
+	return diffLimits{
+		lines:      limit(o.maxDiffLines, "ASSERT_MAX_DIFF_LINES"),
+		paths:      limit(o.maxDiffPaths, "ASSERT_MAX_DIFF_PATHS"),
+		valueBytes: limit(o.maxValueBytes, "ASSERT_MAX_VALUE_BYTES"),
+	}
+}
+
+// cutText returns the part of multi-line texts got and want diffed within
+// the limits. It starts a few lines before their first difference, at the
+// line with the given index, and ends at a line boundary, unless a single
+// line is over the limit. more is the number of bytes cut from the end.
+func (l diffLimits) cutText(got, want string, context int) (gotc, wantc string, line, more int) {
+	fits := func(s string) bool {
+		return (l.valueBytes == 0 || len(s) <= l.valueBytes) &&
+			(l.lines == 0 || strings.Count(s, "\n") <= l.lines)
+	}
+	if fits(got) && fits(want) {
+		return got, want, 0, 0
+	}
+
+	prefix := 0
+	for prefix < len(got) && prefix < len(want) && got[prefix] == want[prefix] {
+		prefix++
+	}
+	start := strings.LastIndexByte(got[:prefix], '\n') + 1
+	for i := 0; i < context && start > 0; i++ {
+		start = strings.LastIndexByte(got[:start-1], '\n') + 1
+	}
+
+	cut := func(s string) string {
+		s = s[start:]
+		if l.lines > 0 {
+			if i := nthIndex(s, '\n', l.lines); i >= 0 {
+				s = s[:i+1]
+			}
+		}
+		if l.valueBytes > 0 && len(s) > l.valueBytes {
+			if i := strings.LastIndexByte(s[:l.valueBytes], '\n'); i >= 0 {
+				s = s[:i+1]
+			} else {
+				s = strings.ToValidUTF8(s[:l.valueBytes], "") + "\n"
+			}
+		}
+		return s
+	}
+
+	gotc, wantc = cut(got), cut(want)
+	more = max(len(got)-start-len(gotc), len(want)-start-len(wantc), 0)
+	return gotc, wantc, strings.Count(got[:start], "\n"), more
+}
+
+// nthIndex returns the index of the n-th occurrence of c in s, or -1.
+func nthIndex(s string, c byte, n int) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == c {
+			if n--; n == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// cutBytes returns the part of binary data got and want diffed within the
+// limits, starting at the offset of the hexdump row before their first
+// difference. more is the number of bytes cut from the end.
+func (l diffLimits) cutBytes(got, want []byte) (gotc, wantc []byte, offset, more int) {
+	n := l.valueBytes
+	if l.lines > 0 && (n == 0 || l.lines*hexRowBytes < n) {
+		n = l.lines * hexRowBytes
+	}
+	if n == 0 || (len(got) <= n && len(want) <= n) {
+		return got, want, 0, 0
+	}
+
+	prefix := 0
+	for prefix < len(got) && prefix < len(want) && got[prefix] == want[prefix] {
+		prefix++
+	}
+	offset = max(prefix/hexRowBytes-1, 0) * hexRowBytes
+
+	gotc, wantc = got[offset:min(len(got), offset+n)], want[offset:min(len(want), offset+n)]
+	more = max(len(got)-offset-len(gotc), len(want)-offset-len(wantc))
+	return gotc, wantc, offset, more
+}
+
+// limitDiff drops lines over the limit.
+// truncated reports whether anything was cut.
+func limitDiff(diff string, limits diffLimits) (out string, truncated bool) {
+	lines := strings.Split(strings.TrimSuffix(diff, "\n"), "\n")
+	if limits.lines == 0 || len(lines) <= limits.lines {
+		return diff, false
+	}
+
+	more := len(lines) - limits.lines
+	lines = append(lines[:limits.lines], fmt.Sprintf("... %d more lines", more))
+	out = strings.Join(lines, "\n")
+	if strings.HasSuffix(diff, "\n") {
+		out += "\n"
+	}
+	return out, true
+}
+
+// truncateLines cuts lines of diff to n bytes, where zero means no limit.
+// truncated reports whether any line was cut.
+func truncateLines(diff string, n int, color bool) (out string, truncated bool) {
+	if n == 0 {
+		return diff, false
+	}
+
+	lines := strings.Split(diff, "\n")
+	for i, line := range lines {
+		if cut, ok := truncateLine(line, n, color); ok {
+			lines[i] = cut
+			truncated = true
+		}
+	}
+	return strings.Join(lines, "\n"), truncated
+}
+
+// truncateLine cuts line to at most n bytes, not counting ANSI escape
+// sequences, at a rune boundary.
+func truncateLine(line string, n int, color bool) (string, bool) {
+	visible := 0
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\x1b' {
+			i = escapeEnd(line, i)
+			continue
+		}
+
+		if visible == n {
+			for i > 0 && !utf8.RuneStart(line[i]) {
+				i--
+			}
+			more := len(stripColors(line[i:]))
+			cut := line[:i] + fmt.Sprintf("... (%d more bytes)", more)
+			if color {
+				cut += colorReset
+			}
+			return cut, true
+		}
+		visible++
+	}
+	return line, false
+}
+
+// stripColors removes ANSI escape sequences from s.
+func stripColors(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\x1b' {
+			i = escapeEnd(s, i)
+			continue
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
+
+// escapeEnd returns the index of the final letter
+// of the ANSI escape sequence starting at i.
+func escapeEnd(s string, i int) int {
+	for i < len(s) && !('a' <= s[i] && s[i] <= 'z' || 'A' <= s[i] && s[i] <= 'Z') {
+		i++
+	}
+	return i
+}
+
+// diffSummary returns a header like "37 differences in 3 top-level fields;
+// showing first 10" describing what a truncated diff shows. limited reports
+// whether differences were left out, and linesCut whether lines were dropped.
+func diffSummary(diffs []difference, shown int, limited bool, lines int, linesCut bool) string {
+	tops := map[string]bool{}
+	for _, d := range diffs {
+		tops[d.top] = true
+	}
+
+	summary := fmt.Sprintf("%s in %s", plural(len(diffs), "difference"), plural(len(tops), "top-level field"))
+	switch {
+	case limited:
+		summary += fmt.Sprintf("; showing first %d", shown)
+	case linesCut:
+		summary += fmt.Sprintf("; showing first %d lines", lines)
+	default:
+		summary += "; long values truncated"
+	}
+	return summary
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// fullDiffNote writes the full diff to a temporary file
+// and returns a note with its path.
+func fullDiffNote(full string) string {
+	f, err := os.CreateTemp("", "assert-diff-*.txt")
+	if err != nil {
+		return fmt.Sprintf("\nfull diff not written: %v", err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(full); err != nil {
+		return fmt.Sprintf("\nfull diff not written: %v", err)
+	}
+	return "\nfull diff: " + f.Name()
+}
@@ -0,0 +1,135 @@
+package assert
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+type limitsT struct {
+	A, B []int
+	C    string
+}
+
+var (
+	limitsGot  = limitsT{A: []int{1, 2, 3, 4}, B: []int{1, 2}, C: strings.Repeat("x", 50)}
+	limitsWant = limitsT{A: []int{0, 0, 0, 0}, B: []int{0, 0}, C: "y"}
+)
+
+// fullDiff returns the content of the full diff file printed in message.
+func fullDiff(t *testing.T, message string) string {
+	t.Helper()
+	_, path, ok := strings.Cut(message, "full diff: ")
+	True(t, ok)
+	return string(Must(os.ReadFile(path)))
+}
+
+func TestEqualMaxDiffPaths(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+
+	atb := &assertTB{TB: t}
+	Equal(atb, limitsGot, limitsWant, MaxDiffPaths(2))
+	atb.fail(t, "expected equal\n7 differences in 3 top-level fields; showing first 2\ndiff:\n")
+	atb.fail(t, " \t\t\"<not shown>\",\n \t\t\"<not shown>\",\n")
+	False(t, strings.Contains(atb.message, "ignored"))
+
+	full := fullDiff(t, atb.message)
+	True(t, strings.Contains(full, `"y"`))
+	False(t, strings.Contains(full, "showing"))
+
+	// fields of pointers are top-level fields
+	atb = &assertTB{TB: t}
+	Equal(atb, &limitsGot, &limitsWant, MaxDiffPaths(1))
+	atb.fail(t, "7 differences in 3 top-level fields; showing first 1\n")
+
+	// no summary without truncation
+	atb = &assertTB{TB: t}
+	Equal(atb, limitsGot, limitsWant, MaxDiffPaths(7))
+	atb.fail(t, "expected equal\ndiff:\n")
+}
+
+func TestEqualMaxDiffLines(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+
+	atb := &assertTB{TB: t}
+	Equal(atb, limitsGot, limitsWant, MaxDiffLines(3))
+	atb.fail(t, "7 differences in 3 top-level fields; showing first 3 lines\ndiff:\n")
+	atb.fail(t, "more lines\n")
+	Len(t, strings.Split(strings.TrimSpace(strings.Split(atb.message, "\n\n")[0]), "\n"), 6)
+}
+
+func TestEqualMaxValueBytes(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+
+	atb := &assertTB{TB: t}
+	Equal(atb, limitsGot, limitsWant, MaxValueBytes(20))
+	atb.fail(t, "; long values truncated\n")
+	atb.fail(t, "more bytes)")
+	True(t, strings.Contains(fullDiff(t, atb.message), strings.Repeat("x", 50)))
+
+	// the line limit is not mentioned if it is not reached
+	atb = &assertTB{TB: t}
+	Equal(atb, limitsGot, limitsWant, MaxDiffLines(100), MaxValueBytes(20))
+	atb.fail(t, "7 differences in 3 top-level fields; long values truncated\n")
+
+	cut, ok := truncateLine(colorRed+"abcdef"+colorReset, 3, true)
+	True(t, ok)
+	Equal(t, cut, colorRed+"abc... (3 more bytes)"+colorReset)
+
+	// runes are not split
+	cut, ok = truncateLine("aąb", 2, false)
+	True(t, ok)
+	Equal(t, cut, "a... (3 more bytes)")
+}
+
+func TestEqualLimitsEnv(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	t.Setenv("ASSERT_MAX_DIFF_PATHS", "1")
+
+	atb := &assertTB{TB: t}
+	Equal(atb, limitsGot, limitsWant)
+	atb.fail(t, "showing first 1\n")
+
+	// options take precedence over environment variables
+	atb = &assertTB{TB: t}
+	Equal(atb, limitsGot, limitsWant, MaxDiffPaths(10))
+	atb.fail(t, "expected equal\ndiff:\n")
+
+	Panic(t, func() { MaxDiffLines(-1) })
+}
+
+func TestEqualLimitsCutValues(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+
+	var got, want strings.Builder
+	for i := range 200 {
+		fmt.Fprintf(&got, "line %d\n", i)
+		if i == 120 {
+			fmt.Fprintf(&want, "LINE %d\n", i)
+		} else {
+			fmt.Fprintf(&want, "line %d\n", i)
+		}
+	}
+
+	// texts are cut around their first difference before they are diffed
+	atb := &assertTB{TB: t}
+	Equal(atb, got.String(), want.String(), MaxValueBytes(100))
+	atb.fail(t, "; long values truncated\ndiff:\n@@ -118,7 +118,7 @@\n line 117\n")
+	atb.fail(t, " line 123\n... (648 more bytes)\n")
+	False(t, strings.Contains(fullDiff(t, atb.message), "more bytes"))
+
+	atb = &assertTB{TB: t}
+	Equal(atb, got.String(), want.String(), MaxDiffLines(10))
+	atb.fail(t, "@@ -118,7 +118,7 @@\n")
+
+	gotb, wantb := make([]byte, 1000), make([]byte, 1000)
+	gotb[0], wantb[0], wantb[500] = 0xff, 0xff, 0x01
+
+	// binary data is cut at the row before its first difference
+	atb = &assertTB{TB: t}
+	Equal(atb, gotb, wantb, MaxValueBytes(64))
+	atb.fail(t, "diff:\n... 480 equal bytes\n 000001e0  00 00")
+	atb.fail(t, "+000001f0  00 00 00 00 01 00")
+	atb.fail(t, "|................|\n... (456 more bytes)\n")
+}
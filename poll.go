@@ -0,0 +1,267 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// deadlineGrace is the time left before the test deadline
+// to report failures of polling assertions.
+const deadlineGrace = time.Second
+
+// Eventually checks if assertions made in fn pass within timeout.
+// fn is called every interval with a testing.TB that records failures,
+// Fatal and FailNow stop only the current attempt.
+//
+//	assert.Eventually(t, 5*time.Second, 100*time.Millisecond, func(t testing.TB) {
+//		assert.Equal(t, queue.Len(), 0)
+//	})
+//
+// The failure message reports the number of attempts and failures
+// of the last attempt. The timeout is shortened to end before the
+// deadline of the test, see [testing.T.Deadline]. An attempt still
+// running at the timeout fails and is left running in the background.
+func Eventually(t testing.TB, timeout, interval time.Duration, fn func(t testing.TB)) bool {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return EventuallyContext(t, ctx, interval, fn)
+}
+
+// EventuallyContext checks if assertions made in fn pass
+// before ctx is done, see [Eventually].
+func EventuallyContext(t testing.TB, ctx context.Context, interval time.Duration, fn func(t testing.TB)) bool {
+	t.Helper()
+	p, ok := poll(t, ctx, interval, fn, func(failures []string) bool { return len(failures) == 0 })
+	if !ok {
+		t.Fatalf("condition not met after %s in %s\nlast failure:\n%s", plural(p.attempts, "attempt"), p.elapsed(), p.failures())
+		return false
+	}
+	return true
+}
+
+// Consistently checks if assertions made in fn keep passing for duration.
+// fn is called every interval, see [Eventually] for details.
+func Consistently(t testing.TB, duration, interval time.Duration, fn func(t testing.TB)) bool {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+	return ConsistentlyContext(t, ctx, interval, fn)
+}
+
+// ConsistentlyContext checks if assertions made in fn keep passing
+// until ctx is done, see [Consistently]. An attempt still running
+// when ctx is done is not checked, unless it runs into the deadline
+// of the test.
+func ConsistentlyContext(t testing.TB, ctx context.Context, interval time.Duration, fn func(t testing.TB)) bool {
+	t.Helper()
+	p, broken := poll(t, ctx, interval, fn, func(failures []string) bool { return len(failures) > 0 })
+	if broken || p.deadline {
+		t.Fatalf("condition failed at attempt %d after %s\n%s", p.attempts, p.elapsed(), p.failures())
+		return false
+	}
+	return true
+}
+
+// Never checks if assertions made in fn never all pass during duration.
+// fn is called every interval, see [Eventually] for details.
+//
+//	assert.Never(t, time.Second, 100*time.Millisecond, func(t testing.TB) {
+//		assert.True(t, cache.Has(key))
+//	})
+func Never(t testing.TB, duration, interval time.Duration, fn func(t testing.TB)) bool {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+	return NeverContext(t, ctx, interval, fn)
+}
+
+// NeverContext checks if assertions made in fn never all pass
+// until ctx is done, see [Never].
+func NeverContext(t testing.TB, ctx context.Context, interval time.Duration, fn func(t testing.TB)) bool {
+	t.Helper()
+	p, passed := poll(t, ctx, interval, fn, func(failures []string) bool { return len(failures) == 0 })
+	if passed {
+		t.Fatalf("condition met at attempt %d after %s", p.attempts, p.elapsed())
+		return false
+	}
+	return true
+}
+
+// polling is the state of a polling assertion.
+type polling struct {
+	start    time.Time
+	attempts int
+	last     []string
+
+	// deadline reports whether an attempt ran into the test deadline.
+	deadline bool
+}
+
+func (p *polling) elapsed() string {
+	return time.Since(p.start).Round(time.Millisecond).String()
+}
+
+func (p *polling) failures() string {
+	return strings.Join(p.last, "\n")
+}
+
+// poll calls fn every interval until stop reports true for failures
+// of an attempt, or ctx or the test deadline is done.
+// ok reports whether polling was stopped by stop.
+func poll(t testing.TB, ctx context.Context, interval time.Duration, fn func(t testing.TB), stop func(failures []string) bool) (p *polling, ok bool) {
+	if interval <= 0 {
+		panic("interval must be positive")
+	}
+
+	parent := ctx
+	if deadline, ok := testDeadline(t); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline.Add(-deadlineGrace))
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	p = &polling{start: time.Now()}
+	for {
+		p.attempts++
+		start := time.Now()
+		failures, finished := attempt(ctx, t, fn)
+		if !finished {
+			// an attempt cut before failing tells nothing, unlike the previous one
+			if len(failures) == 0 {
+				failures = p.last
+			}
+			p.last = append(slices.Clip(failures), fmt.Sprintf("attempt %d timed out after %s", p.attempts, time.Since(start).Round(time.Millisecond)))
+			p.deadline = parent.Err() == nil
+			return p, false
+		}
+		p.last = failures
+		if stop(p.last) {
+			return p, true
+		}
+
+		select {
+		case <-ctx.Done():
+			return p, false
+		case <-ticker.C:
+		}
+	}
+}
+
+// attempt calls fn in a new goroutine, so it can be stopped
+// by Fatal, and returns its failures. finished is false if ctx
+// is done before fn returns, and failures are those recorded so far.
+func attempt(ctx context.Context, t testing.TB, fn func(t testing.TB)) (failures []string, finished bool) {
+	a := &attemptTB{TB: t}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			if r := recover(); r != nil {
+				a.record(fmt.Sprintf("panic: %v", r))
+			}
+		}()
+		fn(a)
+	}()
+
+	select {
+	case <-done:
+		return a.failures, true
+	case <-ctx.Done():
+		// fn may have returned at the same time
+		select {
+		case <-done:
+			return a.failures, true
+		default:
+		}
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		return slices.Clone(a.failures), false
+	}
+}
+
+// testDeadline returns the deadline of the test run by t,
+// looking through testing.TB wrappers of this package.
+func testDeadline(t testing.TB) (time.Time, bool) {
+	for {
+		switch tt := t.(type) {
+		case interface{ Deadline() (time.Time, bool) }:
+			return tt.Deadline()
+		case *softTB:
+			t = tt.TB
+		case *withTB:
+			t = tt.TB
+		case *labelTB:
+			t = tt.TB
+		case *goTB:
+			t = tt.TB
+		case *attemptTB:
+			t = tt.TB
+		case *C:
+			t = tt.TB
+		default:
+			return time.Time{}, false
+		}
+	}
+}
+
+// attemptTB records failures of an attempt of a polling assertion.
+type attemptTB struct {
+	testing.TB
+
+	mu       sync.Mutex
+	failures []string
+}
+
+func (a *attemptTB) record(msg string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.failures = append(a.failures, msg)
+}
+
+func (a *attemptTB) Failed() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.failures) > 0
+}
+
+func (a *attemptTB) Error(args ...any) {
+	a.record(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+func (a *attemptTB) Errorf(format string, args ...any) {
+	a.record(fmt.Sprintf(format, args...))
+}
+
+func (a *attemptTB) Fatal(args ...any) {
+	a.Error(args...)
+	runtime.Goexit()
+}
+
+func (a *attemptTB) Fatalf(format string, args ...any) {
+	a.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+func (a *attemptTB) Fail() {
+	a.record("failed")
+}
+
+func (a *attemptTB) FailNow() {
+	a.record("failed")
+	runtime.Goexit()
+}
+
+// Helper does nothing, as failures are recorded and reported
+// by the polling assertion, and attempts running over the timeout
+// must not touch the test after the assertion returns.
+func (a *attemptTB) Helper() {}
@@ -0,0 +1,144 @@
+package assert
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEventually(t *testing.T) {
+	var n atomic.Int32
+	atb := &assertTB{TB: t}
+	ok := Eventually(atb, 20*time.Millisecond, time.Millisecond, func(t testing.TB) {
+		True(t, n.Add(1) >= 3)
+		t.Fatalf("not reached")
+	})
+	// the last attempt fails only on Fatalf
+	False(t, ok)
+	atb.fail(t, "condition not met after")
+	atb.fail(t, "last failure:\nnot reached")
+
+	n.Store(0)
+	atb = &assertTB{TB: t}
+	ok = Eventually(atb, time.Second, time.Millisecond, func(t testing.TB) {
+		Equal(t, n.Add(1), 3)
+	})
+	True(t, ok)
+	Equal(t, n.Load(), int32(3))
+
+	atb = &assertTB{TB: t}
+	Eventually(atb, 10*time.Millisecond, time.Millisecond, func(t testing.TB) {
+		var m map[string]int
+		m["a"] = 1
+	})
+	atb.fail(t, "last failure:\npanic: assignment to entry in nil map")
+
+	Panic(t, func() { Eventually(t, time.Second, 0, func(t testing.TB) {}) })
+}
+
+func TestEventuallyContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	var n atomic.Int32
+	atb := &assertTB{TB: t}
+	EventuallyContext(atb, ctx, time.Minute, func(t testing.TB) {
+		n.Add(1)
+		t.Errorf("a")
+		t.Errorf("b")
+	})
+	atb.fail(t, "condition not met after 1 attempt in ")
+	atb.fail(t, "last failure:\na\nb")
+	Equal(t, n.Load(), int32(1))
+}
+
+func TestPollAttemptTimeout(t *testing.T) {
+	// attempts running over the timeout fail
+	start := time.Now()
+	atb := &assertTB{TB: t}
+	Eventually(atb, 50*time.Millisecond, time.Millisecond, func(t testing.TB) {
+		t.Errorf("slow")
+		time.Sleep(500 * time.Millisecond)
+	})
+	True(t, time.Since(start) < 300*time.Millisecond)
+	atb.fail(t, "last failure:\nslow\nattempt 1 timed out after ")
+
+	// attempts running over the end of the duration are not checked
+	atb = &assertTB{TB: t}
+	Consistently(atb, 50*time.Millisecond, time.Millisecond, func(t testing.TB) {
+		time.Sleep(20 * time.Millisecond)
+	})
+	atb.pass(t)
+
+	// attempts running into the test deadline fail
+	start = time.Now()
+	dtb := &deadlineTB{assertTB: assertTB{TB: t}, deadline: time.Now().Add(deadlineGrace + 50*time.Millisecond)}
+	Consistently(dtb, time.Minute, time.Millisecond, func(t testing.TB) {
+		time.Sleep(time.Minute)
+	})
+	True(t, time.Since(start) < time.Second)
+	dtb.fail(t, "condition failed at attempt 1 after ")
+	dtb.fail(t, "attempt 1 timed out after ")
+}
+
+// deadlineTB is a testing.TB with a deadline.
+type deadlineTB struct {
+	assertTB
+	deadline time.Time
+}
+
+func (dtb *deadlineTB) Deadline() (time.Time, bool) {
+	return dtb.deadline, true
+}
+
+func TestEventuallyDeadline(t *testing.T) {
+	dtb := &deadlineTB{assertTB: assertTB{TB: t}, deadline: time.Now().Add(deadlineGrace + 50*time.Millisecond)}
+	start := time.Now()
+	Eventually(With(dtb, "deadline"), time.Minute, time.Millisecond, func(t testing.TB) {
+		t.FailNow()
+	})
+	True(t, time.Since(start) < time.Second)
+	dtb.fail(t, "deadline: condition not met after")
+}
+
+func TestConsistently(t *testing.T) {
+	// an attempt cut at the end of the duration may still run
+	var calls atomic.Int32
+	atb := &assertTB{TB: t}
+	ok := Consistently(atb, 20*time.Millisecond, time.Millisecond, func(t testing.TB) {
+		calls.Add(1)
+		True(t, true)
+	})
+	True(t, ok)
+	atb.pass(t)
+	True(t, calls.Load() > 1)
+
+	var n atomic.Int32
+	atb = &assertTB{TB: t}
+	ok = Consistently(atb, time.Second, time.Millisecond, func(t testing.TB) {
+		Zero(t, n.Add(1)/3)
+	})
+	False(t, ok)
+	atb.fail(t, "condition failed at attempt 3 after ")
+	atb.fail(t, "n.Add(1)/3: expected zero, got int32(1)")
+}
+
+func TestNever(t *testing.T) {
+	atb := &assertTB{TB: t}
+	ok := Never(atb, 20*time.Millisecond, time.Millisecond, func(t testing.TB) {
+		False(t, true)
+	})
+	True(t, ok)
+	atb.pass(t)
+
+	var n atomic.Int32
+	atb = &assertTB{TB: t}
+	ok = Never(atb, time.Second, time.Millisecond, func(t testing.TB) {
+		True(t, n.Add(1) == 2)
+	})
+	False(t, ok)
+	atb.fail(t, "condition met at attempt 2 after ")
+	True(t, !strings.Contains(atb.message, "\n"))
+}
@@ -0,0 +1,489 @@
+package assert
+
+import (
+	"fmt"
+	"go/format"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// printer renders values in a multi-line Go composite literal syntax.
+type printer struct {
+	sb strings.Builder
+
+	// eq provides formatters of values and redacted fields.
+	eq *equaler
+
+	// visited are pointers, maps and slices being printed, used to detect cycles.
+	visited map[visit]bool
+
+	// oneLine writes composite literals on a single line.
+	oneLine bool
+
+	// name is the canonical path of the printed value, see pathString,
+	// and fields is the path of struct fields, see fieldPath.
+	name, fields string
+
+	// field is the struct field holding the printed value.
+	field *reflect.StructField
+
+	// differs are names of differing values. If nil,
+	// redacted values are not compared.
+	differs map[string]bool
+
+	// hidden are names of differing values left out of a diff,
+	// see MaxDiffPaths, which are written as "<not shown>".
+	hidden map[string]bool
+
+	// redacted reports whether a redacted value was printed.
+	redacted bool
+}
+
+// visit is a pointer, map or slice being printed. Slices sharing
+// the data pointer differ by length, and pointers to a struct
+// and to its first field differ by type.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// Sprint returns v formatted as a gofmt-ed Go composite literal,
+// which can be copied into a test, e.g. as the new want value.
+//
+// Maps are sorted by keys, pointers are followed, and cycles through
+// pointers, maps and slices are marked with a comment, and [time.Time] is written as a call to [time.Date].
+// Unexported fields are included and marked with a comment, as they
+// can be set only within their package. Values without a literal syntax,
+// like channels and functions, are written as a conversion of their address.
+//
+// Values of types with formatters, see [RegisterFormatter],
+// are written as quoted strings, and redacted values, see [RedactFields],
+// as "<redacted>".
+func Sprint(v any) string {
+	out, _ := newEqualer().sprint(v, nil, nil)
+	return out
+}
+
+// sprint returns v formatted as a Go composite literal, using formatters
+// and redacted fields of the equaler. Redacted values are marked as
+// differing if their names, or names of values holding them, are in differs.
+// Values with names in hidden are written as "<not shown>".
+// redacted reports whether any value was redacted.
+func (o *equaler) sprint(v any, differs, hidden map[string]bool) (out string, redacted bool) {
+	rv := reflect.ValueOf(v)
+	p := &printer{eq: o, visited: map[visit]bool{}, differs: differs, hidden: hidden}
+	if rv.IsValid() && rv.Type().PkgPath() != "" {
+		p.name = rv.Type().Name()
+	}
+	p.print(rv, 0, true)
+	out = p.sb.String()
+
+	// format as a declaration, as go/format does not accept expressions
+	const decl = "var _ = "
+	formatted, err := format.Source([]byte(decl + out))
+	if err != nil {
+		return out, p.redacted
+	}
+	return strings.TrimPrefix(string(formatted), decl), p.redacted
+}
+
+// sprintLine returns v formatted on a single line. name and fields
+// are the paths of v, used to find redacted values.
+func (o *equaler) sprintLine(v reflect.Value, name, fields string, differs map[string]bool) string {
+	p := &printer{eq: o, visited: map[visit]bool{}, oneLine: true, name: name, fields: fields, differs: differs}
+	p.print(v, 0, true)
+	return p.sb.String()
+}
+
+// differing reports whether the printed value, or a value holding it, differs.
+func (p *printer) differing() bool {
+	for name := range p.differs {
+		// an empty name is the whole compared value
+		if name == "" || name == p.name || strings.HasPrefix(p.name, name) && strings.ContainsRune(".[", rune(p.name[len(name)])) {
+			return true
+		}
+	}
+	return false
+}
+
+// redact writes a placeholder of a redacted value.
+func (p *printer) redact() {
+	p.redacted = true
+	switch {
+	case p.differs == nil:
+		p.sb.WriteString(`"<redacted>"`)
+	case p.differing():
+		p.sb.WriteString(`"<redacted: differs>"`)
+	default:
+		p.sb.WriteString(`"<redacted: equal>"`)
+	}
+}
+
+func (p *printer) indent(depth int) {
+	p.sb.WriteString(strings.Repeat("\t", depth))
+}
+
+// open starts the elements of a composite literal.
+func (p *printer) open() {
+	if p.oneLine {
+		p.sb.WriteString("{")
+	} else {
+		p.sb.WriteString("{\n")
+	}
+}
+
+// element starts the i-th element of a composite literal.
+func (p *printer) element(i, depth int) {
+	switch {
+	case !p.oneLine:
+		p.indent(depth + 1)
+	case i > 0:
+		p.sb.WriteString(", ")
+	}
+}
+
+// close ends the elements of a composite literal.
+func (p *printer) close(depth int) {
+	if !p.oneLine {
+		p.indent(depth)
+	}
+	p.sb.WriteString("}")
+}
+
+// enter sets the path of a nested value and returns a function restoring it.
+func (p *printer) enter(name, fields string) func() {
+	prevName, prevFields := p.name, p.fields
+	p.name, p.fields = name, fields
+	return func() { p.name, p.fields = prevName, prevFields }
+}
+
+// print writes v at the indentation depth. typed reports whether
+// the type must be written, which is not needed for elements
+// of slices, arrays and maps.
+func (p *printer) print(v reflect.Value, depth int, typed bool) {
+	field := p.field
+	p.field = nil
+
+	if p.hidden[p.name] {
+		p.sb.WriteString(`"<not shown>"`)
+		return
+	}
+
+	if !v.IsValid() {
+		p.sb.WriteString("nil")
+		return
+	}
+
+	if p.eq.redacted(v.Type(), field, p.fields) {
+		p.redact()
+		return
+	}
+
+	if s, ok := p.eq.format(v); ok {
+		p.sb.WriteString(strconv.Quote(s))
+		return
+	}
+
+	if v.Type() == reflect.TypeFor[time.Time]() {
+		if tm, ok := interfaceOf(v); ok {
+			p.time(tm.(time.Time))
+			return
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		p.scalar(v, strconv.FormatBool(v.Bool()), typed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		p.scalar(v, strconv.FormatInt(v.Int(), 10), typed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		p.scalar(v, strconv.FormatUint(v.Uint(), 10), typed)
+	case reflect.Float32, reflect.Float64:
+		p.scalar(v, formatFloat(v.Float(), v.Type().Bits()), typed)
+	case reflect.Complex64, reflect.Complex128:
+		p.scalar(v, strconv.FormatComplex(v.Complex(), 'g', -1, v.Type().Bits()), typed)
+	case reflect.String:
+		p.scalar(v, strconv.Quote(v.String()), typed)
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			p.sb.WriteString(nilOf(v.Type()))
+			return
+		}
+		if !p.enterRef(v) {
+			fmt.Fprintf(&p.sb, "&%s{/* cycle */}", v.Type().Elem())
+			return
+		}
+		defer p.leaveRef(v)
+
+		switch v.Elem().Kind() {
+		case reflect.Struct, reflect.Array, reflect.Slice, reflect.Map:
+			if v.Elem().Type() != reflect.TypeFor[time.Time]() {
+				p.sb.WriteString("&")
+				p.print(v.Elem(), depth, true)
+				return
+			}
+		}
+
+		// only composite literals can be addressed,
+		// so other values are wrapped in a slice
+		fmt.Fprintf(&p.sb, "&[]%s{", v.Type().Elem())
+		p.print(v.Elem(), depth, false)
+		p.sb.WriteString("}[0]")
+
+	case reflect.Interface:
+		if v.IsNil() {
+			p.sb.WriteString("nil")
+			return
+		}
+		p.print(v.Elem(), depth, true)
+
+	case reflect.Slice:
+		if v.IsNil() {
+			p.sb.WriteString(nilOf(v.Type()))
+			return
+		}
+		if !p.enterRef(v) {
+			fmt.Fprintf(&p.sb, "%s{/* cycle */}", v.Type())
+			return
+		}
+		defer p.leaveRef(v)
+		p.list(v, depth, typed)
+
+	case reflect.Array:
+		p.list(v, depth, typed)
+
+	case reflect.Map:
+		if v.IsNil() {
+			p.sb.WriteString(nilOf(v.Type()))
+			return
+		}
+		if !p.enterRef(v) {
+			fmt.Fprintf(&p.sb, "%s{/* cycle */}", v.Type())
+			return
+		}
+		defer p.leaveRef(v)
+		p.mapValue(v, depth, typed)
+
+	case reflect.Struct:
+		p.structValue(v, depth, typed)
+
+	default:
+		// chan, func and unsafe pointer have no literal syntax
+		if v.IsNil() {
+			p.sb.WriteString(nilOf(v.Type()))
+			return
+		}
+		fmt.Fprintf(&p.sb, "(%s)(%#x)", v.Type(), v.Pointer())
+	}
+}
+
+// enterRef marks the non-nil pointer, map or slice v as being printed.
+// It reports false if v is already being printed, so it is a cycle.
+// Empty maps and slices are not marked, as they do not hold any values
+// and empty slices may share the data pointer.
+func (p *printer) enterRef(v reflect.Value) bool {
+	if v.Kind() != reflect.Pointer && v.Len() == 0 {
+		return true
+	}
+
+	key := refKey(v)
+	if p.visited[key] {
+		return false
+	}
+	p.visited[key] = true
+	return true
+}
+
+// leaveRef unmarks v marked by enterRef.
+func (p *printer) leaveRef(v reflect.Value) {
+	delete(p.visited, refKey(v))
+}
+
+func refKey(v reflect.Value) visit {
+	key := visit{ptr: v.Pointer(), typ: v.Type()}
+	if v.Kind() == reflect.Slice {
+		key.len = v.Len()
+	}
+	return key
+}
+
+// scalar writes a basic literal, converted to its type if needed.
+func (p *printer) scalar(v reflect.Value, lit string, typed bool) {
+	if typed && (v.Type().PkgPath() != "" || !isDefaultType(v)) {
+		fmt.Fprintf(&p.sb, "%s(%s)", v.Type(), lit)
+		return
+	}
+	p.sb.WriteString(lit)
+}
+
+// isDefaultType reports whether v has the default type of its untyped literal.
+func isDefaultType(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Float64, reflect.String, reflect.Complex128:
+		return true
+	default:
+		return false
+	}
+}
+
+func nilOf(t reflect.Type) string {
+	return fmt.Sprintf("(%s)(nil)", t)
+}
+
+func (p *printer) list(v reflect.Value, depth int, typed bool) {
+	if typed {
+		p.sb.WriteString(v.Type().String())
+	}
+	if v.Len() == 0 {
+		p.sb.WriteString("{}")
+		return
+	}
+
+	p.open()
+	for i := range v.Len() {
+		p.element(i, depth)
+		restore := p.enter(fmt.Sprintf("%s[%d]", p.name, i), p.fields)
+		p.print(v.Index(i), depth+1, false)
+		restore()
+		p.separator()
+	}
+	p.close(depth)
+}
+
+// separator ends an element of a composite literal.
+func (p *printer) separator() {
+	if !p.oneLine {
+		p.sb.WriteString(",\n")
+	}
+}
+
+func (p *printer) mapValue(v reflect.Value, depth int, typed bool) {
+	if typed {
+		p.sb.WriteString(v.Type().String())
+	}
+	if v.Len() == 0 {
+		p.sb.WriteString("{}")
+		return
+	}
+
+	type entry struct {
+		key, value reflect.Value
+		sortKey    string
+	}
+	entries := make([]entry, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		kp := &printer{eq: p.eq, visited: p.visited}
+		kp.print(iter.Key(), 0, false)
+		entries = append(entries, entry{
+			key:     iter.Key(),
+			value:   iter.Value(),
+			sortKey: kp.sb.String(),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return lessValue(entries[i].key, entries[j].key, entries[i].sortKey, entries[j].sortKey)
+	})
+
+	p.open()
+	for i, e := range entries {
+		p.element(i, depth)
+		p.print(e.key, depth+1, false)
+		p.sb.WriteString(": ")
+		restore := p.enter(fmt.Sprintf("%s[%#v]", p.name, e.key), p.fields)
+		p.print(e.value, depth+1, false)
+		restore()
+		p.separator()
+	}
+	p.close(depth)
+}
+
+// lessValue orders map keys by value for numbers
+// and by their string representation otherwise.
+func lessValue(a, b reflect.Value, as, bs string) bool {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	default:
+		return as < bs
+	}
+}
+
+func (p *printer) structValue(v reflect.Value, depth int, typed bool) {
+	t := v.Type()
+	if typed {
+		p.sb.WriteString(t.String())
+	}
+	if t.NumField() == 0 {
+		p.sb.WriteString("{}")
+		return
+	}
+
+	p.open()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		p.element(i, depth)
+		p.sb.WriteString(field.Name)
+		p.sb.WriteString(": ")
+
+		restore := p.enter(joinPath(p.name, field.Name), joinPath(p.fields, field.Name))
+		p.field = &field
+		p.print(v.Field(i), depth+1, true)
+		restore()
+
+		if !p.oneLine {
+			p.sb.WriteString(",")
+			if !field.IsExported() {
+				p.sb.WriteString(" // unexported")
+			}
+			p.sb.WriteString("\n")
+		}
+	}
+	p.close(depth)
+}
+
+// time writes tm as a call to time.Date.
+func (p *printer) time(tm time.Time) {
+	var loc string
+	switch name, offset := tm.Zone(); {
+	case tm.Location() == time.UTC:
+		loc = "time.UTC"
+	case tm.Location() == time.Local:
+		loc = "time.Local"
+	default:
+		loc = fmt.Sprintf("time.FixedZone(%q, %d)", name, offset)
+	}
+
+	fmt.Fprintf(&p.sb, "time.Date(%d, time.%s, %d, %d, %d, %d, %d, %s)",
+		tm.Year(), tm.Month(), tm.Day(), tm.Hour(), tm.Minute(), tm.Second(), tm.Nanosecond(), loc)
+}
+
+// formatFloat formats f, using functions of the math package
+// for values without a literal syntax.
+func formatFloat(f float64, bits int) string {
+	switch {
+	case math.IsNaN(f):
+		return "math.NaN()"
+	case math.IsInf(f, 1):
+		return "math.Inf(1)"
+	case math.IsInf(f, -1):
+		return "math.Inf(-1)"
+	}
+
+	lit := strconv.FormatFloat(f, 'g', -1, bits)
+	if !strings.ContainsAny(lit, ".eIN") {
+		// keep the literal a float for untyped contexts
+		lit += ".0"
+	}
+	return lit
+}
@@ -0,0 +1,102 @@
+package assert
+
+import (
+	"go/parser"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSprint(t *testing.T) {
+	type ID int
+	type T struct {
+		A  int
+		B  string
+		C  []int
+		D  map[string]ID
+		e  *T
+		F  any
+		G  []byte
+		H  int64
+		Ch chan int
+	}
+
+	v := &T{
+		A: 1,
+		B: "b",
+		C: []int{1, 2},
+		D: map[string]ID{"y": 2, "x": 1},
+		F: uint8(3),
+		G: []byte{},
+		H: 4,
+	}
+	v.e = v
+
+	want := `&assert.T{
+	A: 1,
+	B: "b",
+	C: []int{
+		1,
+		2,
+	},
+	D: map[string]assert.ID{
+		"x": 1,
+		"y": 2,
+	},
+	e:  &assert.T{ /* cycle */ }, // unexported
+	F:  uint8(3),
+	G:  []uint8{},
+	H:  int64(4),
+	Ch: (chan int)(nil),
+}`
+	Equal(t, Sprint(v), want)
+	Equal(t, Sprint(nil), "nil")
+	Equal(t, Sprint(map[int]bool{10: true, 9: false}), "map[int]bool{\n\t9:  false,\n\t10: true,\n}")
+}
+
+func TestSprintCycles(t *testing.T) {
+	m := map[string]any{"a": 1}
+	m["self"] = m
+	Equal(t, Sprint(m), "map[string]interface{}{\n\t\"a\":    1,\n\t\"self\": map[string]interface{}{ /* cycle */ },\n}")
+
+	s := []any{1, nil}
+	s[1] = s
+	Equal(t, Sprint(s), "[]interface{}{\n\t1,\n\t[]interface{}{ /* cycle */ },\n}")
+
+	// a struct and its first field share the address
+	type T struct {
+		A int
+		P any
+	}
+	v := &T{}
+	v.P = &v.A
+	Equal(t, Sprint(v), "&assert.T{\n\tA: 0,\n\tP: &[]int{0}[0],\n}")
+}
+
+func TestSprintValues(t *testing.T) {
+	n := 5
+	warsaw := time.FixedZone("CET", 3600)
+
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{value: 1.0, want: "1.0"},
+		{value: float32(2.5), want: "float32(2.5)"},
+		{value: math.Inf(-1), want: "math.Inf(-1)"},
+		{value: &n, want: "&[]int{5}[0]"},
+		{value: []*int{nil}, want: "[]*int{\n\t(*int)(nil),\n}"},
+		{value: time.Date(2024, time.March, 1, 12, 30, 0, 5, time.UTC), want: "time.Date(2024, time.March, 1, 12, 30, 0, 5, time.UTC)"},
+		{value: time.Date(2024, time.March, 1, 12, 30, 0, 0, warsaw), want: `time.Date(2024, time.March, 1, 12, 30, 0, 0, time.FixedZone("CET", 3600))`},
+		{value: []time.Time{{}}, want: "[]time.Time{\n\ttime.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC),\n}"},
+	}
+
+	for _, tt := range tests {
+		got := Sprint(tt.value)
+		Equal(t, got, tt.want)
+
+		// output is valid Go syntax
+		_, err := parser.ParseExpr(got)
+		NoError(t, err)
+	}
+}
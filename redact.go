@@ -0,0 +1,144 @@
+package assert
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// redactors are types registered with [RegisterRedactor].
+var redactors sync.Map
+
+// RegisterRedactor registers T as a type of secret values, like tokens
+// or passwords, which are redacted in failure messages of all assertions.
+// It is usually called in TestMain or in an init function.
+//
+// See [RedactFields] for how redacted values are shown.
+func RegisterRedactor[T any]() {
+	redactors.Store(reflect.TypeFor[T](), true)
+}
+
+// RedactFields returns an EqualOption that redacts values of struct fields
+// in failure messages. Paths are dot-delimited names of fields, relative to
+// the compared value, where slice indices and map keys are omitted,
+// e.g. "Users.Password" redacts the Password field of all users.
+//
+// Fields can be also redacted with the `assert:"redact"` struct tag,
+// and types with [RegisterRedactor].
+//
+// Redacted values are still compared, but diffs show only
+// "<redacted: differs>" or "<redacted: equal>" instead of their content.
+// Diffs of values with redacted fields are rendered as line-based diffs
+// of the values in Go syntax, followed by paths of differing redacted values.
+// Fields ignored by other options are still shown, but they are not compared.
+// JSON artifacts of such values are not written, see [ArtifactsDir].
+func RedactFields(paths ...string) EqualOption {
+	return func(o *equaler) {
+		o.redactFields = append(o.redactFields, paths...)
+	}
+}
+
+// redacted reports whether a value of type t, held by the struct field
+// at the path of fields, must be redacted. field is nil for values
+// that are not held by struct fields.
+func (o *equaler) redacted(t reflect.Type, field *reflect.StructField, fields string) bool {
+	if field != nil {
+		if tag, ok := field.Tag.Lookup("assert"); ok && slices.Contains(strings.Split(tag, ","), "redact") {
+			return true
+		}
+		if slices.Contains(o.redactFields, fields) {
+			return true
+		}
+	}
+	_, ok := redactors.Load(t)
+	return ok
+}
+
+// hasRedacted reports whether a value of type t may hold redacted values,
+// looking at the types of all values it can reach. Types of values held
+// by interfaces are not known, they are found by [diffReporter] instead.
+func (o *equaler) hasRedacted(t reflect.Type) bool {
+	return t != nil && o.typeRedacted(t, "", true, map[redactVisit]bool{})
+}
+
+// redactVisit is a type reached by [equaler.typeRedacted] at the path of
+// fields, which is kept only while it can lead to a path of RedactFields.
+type redactVisit struct {
+	t      reflect.Type
+	fields string
+}
+
+func (o *equaler) typeRedacted(t reflect.Type, fields string, tracked bool, seen map[redactVisit]bool) bool {
+	if _, ok := redactors.Load(t); ok {
+		return true
+	}
+
+	tracked = tracked && slices.ContainsFunc(o.redactFields, func(path string) bool {
+		return fields == "" || strings.HasPrefix(path, fields+".")
+	})
+	if !tracked {
+		fields = ""
+	}
+	key := redactVisit{t, fields}
+	if seen[key] {
+		return false
+	}
+	seen[key] = true
+
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return o.typeRedacted(t.Elem(), fields, tracked, seen)
+	case reflect.Map:
+		return o.typeRedacted(t.Key(), fields, tracked, seen) ||
+			o.typeRedacted(t.Elem(), fields, tracked, seen)
+	case reflect.Struct:
+		for i := range t.NumField() {
+			f := t.Field(i)
+			path := joinPath(fields, f.Name)
+			if o.redacted(f.Type, &f, path) || o.typeRedacted(f.Type, path, tracked, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// redactPrefix returns the shortest prefix of p pointing to a redacted value.
+func (o *equaler) redactPrefix(p cmp.Path) (cmp.Path, bool) {
+	fields := ""
+	for i := 0; i < len(p); i++ {
+		var field *reflect.StructField
+		if sf, ok := p.Index(i).(cmp.StructField); ok && i > 0 {
+			f := p.Index(i - 1).Type().Field(sf.Index())
+			field = &f
+			fields = joinPath(fields, sf.Name())
+		}
+
+		if t := p.Index(i).Type(); t != nil && o.redacted(t, field, fields) {
+			return p[:i+1], true
+		}
+	}
+	return nil, false
+}
+
+// fieldPath returns the dot-delimited names of struct fields in p.
+func fieldPath(p cmp.Path) string {
+	fields := ""
+	for _, ps := range p {
+		if sf, ok := ps.(cmp.StructField); ok {
+			fields = joinPath(fields, sf.Name())
+		}
+	}
+	return fields
+}
+
+// joinPath appends the field name to the dot-delimited path.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
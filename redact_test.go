@@ -0,0 +1,106 @@
+package assert
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testToken string
+
+func TestEqualRedact(t *testing.T) {
+	RegisterRedactor[testToken]()
+	t.Cleanup(func() { redactors.Delete(reflect.TypeFor[testToken]()) })
+
+	type Credential struct {
+		User     string
+		Password string `assert:"redact"`
+		APIKey   string
+	}
+	type Config struct {
+		Name        string
+		Credentials []Credential
+		Token       testToken
+	}
+
+	got := Config{
+		Name:        "a",
+		Credentials: []Credential{{"alice", "secret-1", "key-1"}, {"bob", "secret-2", "key-2"}},
+		Token:       "token-1",
+	}
+	want := Config{
+		Name:        "b",
+		Credentials: []Credential{{"alice", "secret-1", "key-9"}},
+		Token:       "token-1",
+	}
+
+	atb := &assertTB{TB: t}
+	Equal(atb, got, want, RedactFields("Credentials.APIKey"))
+	atb.fail(t, " \t\t\tPassword: \"<redacted: equal>\",\n \t\t\tAPIKey:   \"<redacted: differs>\",\n")
+	atb.fail(t, "-\t\t\tPassword: \"<redacted: differs>\",\n")
+	atb.fail(t, " \tToken: \"<redacted: equal>\",\n }\nConfig.Credentials[0].APIKey: <redacted: differs>\n")
+	for _, secret := range []string{"secret", "key-", "token-"} {
+		False(t, strings.Contains(atb.message, secret))
+	}
+
+	atb = &assertTB{TB: t}
+	Equal(atb, got, want, RedactFields("Credentials.APIKey"), PathList())
+	atb.fail(t, "diff:\n"+
+		"Config.Name: got \"a\", want \"b\"\n"+
+		"Config.Credentials[0].APIKey: <redacted: differs>\n"+
+		"Config.Credentials[1]: got assert.Credential{User: \"bob\", Password: \"<redacted>\", APIKey: \"<redacted>\"}, want <missing>\n")
+
+	// redacted values are still compared
+	atb = &assertTB{TB: t}
+	Equal(atb, Credential{Password: "a"}, Credential{Password: "b"})
+	atb.fail(t, "expected equal\ndiff:\nCredential.Password: <redacted: differs>\n")
+
+	atb = &assertTB{TB: t}
+	Zero(atb, got)
+	atb.fail(t, "Token: \"<redacted>\"")
+	False(t, strings.Contains(atb.message, "secret"))
+}
+
+func TestEqualRedactArtifacts(t *testing.T) {
+	type Login struct {
+		Password string `assert:"redact"`
+	}
+
+	dir := t.TempDir()
+	atb := &assertTB{TB: t}
+	Equal(atb, Login{"a"}, Login{"b"}, ArtifactsDir(dir))
+	atb.fail(t, ".got.json: skipped, values have redacted fields")
+}
+
+func TestEqualRedactNotReported(t *testing.T) {
+	type Login struct {
+		User     string
+		Password string `assert:"redact"`
+	}
+
+	// cmp does not report fields of values missing on one side
+	alice, bob := Login{"alice", "a"}, Login{"bob", "hunter2"}
+	tests := []struct {
+		name string
+		diff func(atb *assertTB)
+	}{
+		{"missing map entry", func(atb *assertTB) {
+			Equal(atb, map[string]Login{"x": bob}, map[string]Login{})
+		}},
+		{"nil pointer", func(atb *assertTB) {
+			Equal(atb, &bob, nil)
+		}},
+		{"extra slice element", func(atb *assertTB) {
+			Equal(atb, []Login{alice, bob}, []Login{alice})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atb := &assertTB{TB: t}
+			tt.diff(atb)
+			atb.fail(t, `Password: "<redacted: differs>"`)
+			False(t, strings.Contains(atb.message, "hunter2"))
+		})
+	}
+}
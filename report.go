@@ -0,0 +1,241 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// PathList returns an EqualOption that reports differences as a flat list
+// with one line per differing value, e.g.
+//
+//	User.Address.City: got "Krakow", want "Warsaw"
+//
+// Paths consist of field names, slice indices and map keys in brackets,
+// see also [JSONEq] for paths in JSON documents. Values missing
+// on one side are shown as <missing>.
+//
+// Path lists can be enabled for all assertions by setting
+// the ASSERT_DIFF environment variable to "paths".
+func PathList() EqualOption {
+	return func(o *equaler) {
+		o.diffStyle = diffPaths
+	}
+}
+
+// difference is a differing value found by [diffReporter].
+type difference struct {
+	key    string // GoString of the path, used to ignore the value
+	name   string // canonical path, see pathString
+	fields string // path of struct fields, see fieldPath
+	top    string // canonical path of the top-level field holding the value
+
+	// redacted reports whether the value is redacted, see RedactFields.
+	redacted bool
+
+	got, want reflect.Value
+}
+
+func newDifference(p cmp.Path) difference {
+	got, want := p.Last().Values()
+	return difference{
+		key:    p.GoString(),
+		name:   pathString(p),
+		fields: fieldPath(p),
+		top:    pathString(topPath(p)),
+		got:    got,
+		want:   want,
+	}
+}
+
+// topPath returns the prefix of p up to the top-level field,
+// map entry or element holding the value, skipping pointers
+// and interfaces of the root value.
+func topPath(p cmp.Path) cmp.Path {
+	for i := 1; i < len(p); i++ {
+		switch p[i].(type) {
+		case cmp.Indirect, cmp.TypeAssertion:
+		default:
+			return p[:i+1]
+		}
+	}
+	return p
+}
+
+// pathLine returns the difference as a line of a path list.
+func (o *equaler) pathLine(d difference) string {
+	line := "<redacted: differs>"
+	if !d.redacted {
+		line = fmt.Sprintf("got %s, want %s", o.formatLeaf(d, d.got), o.formatLeaf(d, d.want))
+	}
+	if d.name != "" {
+		line = d.name + ": " + line
+	}
+	return line
+}
+
+// formatLeaf formats a differing value v of d in Go syntax on a single line.
+func (o *equaler) formatLeaf(d difference, v reflect.Value) string {
+	if !v.IsValid() {
+		return "<missing>"
+	}
+	if s, ok := o.format(v); ok {
+		return strconv.Quote(s)
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			return "&" + o.formatLeaf(d, v.Elem())
+		}
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map, reflect.Interface:
+		// the printer redacts nested values
+		return o.sprintLine(v, d.name, d.fields, nil)
+	}
+	return fmt.Sprintf("%#v", v)
+}
+
+// pathList returns differences as lines of a path list.
+func (o *equaler) pathList(diffs []difference) string {
+	var sb strings.Builder
+	for _, d := range diffs {
+		sb.WriteString(o.pathLine(d))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// diffReporter is a [cmp.Reporter] collecting differing values.
+//
+// Path steps are reused by cmp during the comparison,
+// so differences are captured when reported.
+type diffReporter struct {
+	eq   *equaler
+	path cmp.Path
+	seen map[string]bool
+
+	// diffs are differing values, in order of the comparison. Differences
+	// within texts and binary data are reported once for the whole value.
+	diffs []difference
+
+	// texts are differing multi-line texts, rendered as unified diffs.
+	texts []difference
+
+	// binaries are differing byte slices and arrays
+	// that are not valid UTF-8, rendered as hexdumps.
+	binaries []difference
+
+	// formatted are differing values of types with formatters.
+	formatted []difference
+
+	// redacted reports whether any compared value is redacted.
+	redacted bool
+}
+
+func (r *diffReporter) PushStep(ps cmp.PathStep) {
+	r.path = append(r.path, ps)
+}
+
+func (r *diffReporter) PopStep() {
+	r.path = r.path[:len(r.path)-1]
+}
+
+func (r *diffReporter) Report(rs cmp.Result) {
+	if rs.Equal() {
+		// equal redacted values may be shown as context of diffs
+		if !r.redacted {
+			_, r.redacted = r.eq.redactPrefix(r.path)
+		}
+		return
+	}
+
+	var d difference
+	if p, ok := r.eq.redactPrefix(r.path); ok {
+		d = newDifference(p)
+		d.redacted = true
+		r.redacted = true
+	} else if p, ok := r.eq.formatPrefix(r.path); ok {
+		d = newDifference(p)
+		if !r.seen[d.key] {
+			r.formatted = append(r.formatted, d)
+		}
+	} else if p, ok := binaryPrefix(r.path); ok {
+		d = newDifference(p)
+		if !r.seen[d.key] {
+			r.binaries = append(r.binaries, d)
+		}
+	} else if p, ok := textPrefix(r.path); ok {
+		d = newDifference(p)
+		if !r.seen[d.key] {
+			r.texts = append(r.texts, d)
+		}
+	} else {
+		d = newDifference(r.path)
+	}
+
+	if !r.seen[d.key] {
+		r.seen[d.key] = true
+		r.diffs = append(r.diffs, d)
+	}
+}
+
+// diffNames returns names of differing values.
+func (r *diffReporter) diffNames() map[string]bool {
+	names := make(map[string]bool, len(r.diffs))
+	for _, d := range r.diffs {
+		names[d.name] = true
+	}
+	return names
+}
+
+// collectDiffs compares a and b and returns the reporter with their differences.
+func collectDiffs(a, b any, eq *equaler, opts cmp.Options) *diffReporter {
+	r := &diffReporter{eq: eq, seen: map[string]bool{}}
+	cmp.Equal(a, b, append(opts, cmp.Reporter(r))...)
+	return r
+}
+
+// ignoreDiffs returns an [cmp.Option] that ignores the given differences.
+func ignoreDiffs(diffs []difference) cmp.Option {
+	keys := make(map[string]bool, len(diffs))
+	for _, d := range diffs {
+		keys[d.key] = true
+	}
+	return cmp.FilterPath(func(p cmp.Path) bool { return keys[p.GoString()] }, cmp.Ignore())
+}
+
+// pathString returns a canonical path of a value, e.g. User.Addresses[0].City.
+//
+// The path starts with the name of the root type if it is a named type,
+// and consists of field names, slice indices in brackets
+// and map keys in brackets.
+func pathString(p cmp.Path) string {
+	var sb strings.Builder
+	if len(p) > 0 {
+		if t := p[0].Type(); t != nil && t.PkgPath() != "" {
+			sb.WriteString(t.Name())
+		}
+	}
+
+	for _, ps := range p[min(len(p), 1):] {
+		switch ps := ps.(type) {
+		case cmp.StructField:
+			if sb.Len() > 0 {
+				sb.WriteString(".")
+			}
+			sb.WriteString(ps.Name())
+		case cmp.SliceIndex:
+			i, j := ps.SplitKeys()
+			if i < 0 {
+				i = j
+			}
+			fmt.Fprintf(&sb, "[%d]", i)
+		case cmp.MapIndex:
+			fmt.Fprintf(&sb, "[%#v]", ps.Key())
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,42 @@
+package assert
+
+import (
+	"testing"
+)
+
+func TestEqualPathList(t *testing.T) {
+	type Address struct {
+		City string
+		Zip  *int
+	}
+	type User struct {
+		Name    string
+		Address Address
+		Tags    []string
+		Meta    map[string]int
+		Ignored int
+		private int
+	}
+
+	zip := 31000
+	got := User{Name: "a", Address: Address{City: "Krakow", Zip: &zip}, Tags: []string{"x", "y"}, Meta: map[string]int{"a": 1}, Ignored: 1, private: 1}
+	want := User{Name: "a", Address: Address{City: "Warsaw"}, Tags: []string{"x"}, Meta: map[string]int{"b": 1}}
+
+	atb := &assertTB{TB: t}
+	Equal(atb, got, want, PathList(), IgnoreUnexported(), SkipFieldNames("Ignored"))
+	atb.fail(t, "expected equal\ndiff:\n"+
+		`User.Address.City: got "Krakow", want "Warsaw"`+"\n"+
+		`User.Address.Zip: got &31000, want (*int)(nil)`+"\n"+
+		`User.Tags[1]: got "y", want <missing>`+"\n"+
+		`User.Meta["a"]: got 1, want <missing>`+"\n"+
+		`User.Meta["b"]: got <missing>, want 1`+"\n")
+
+	t.Setenv("ASSERT_DIFF", "paths")
+	atb = &assertTB{TB: t}
+	Equal(atb, []int{1, 2}, []int{1, 3}, MaxDiffPaths(1))
+	atb.fail(t, "diff:\n[1]: got 2, want 3\n")
+
+	atb = &assertTB{TB: t}
+	Equal(atb, 1, 2)
+	atb.fail(t, "diff:\ngot 1, want 2\n")
+}
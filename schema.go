@@ -0,0 +1,474 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// MatchesJSONSchema checks if the JSON document conforms to the JSON schema.
+//
+// It implements a practical subset of the JSON Schema draft 2020-12:
+//
+//   - type, enum, const
+//   - allOf, anyOf, oneOf, not
+//   - minimum, maximum, exclusiveMinimum, exclusiveMaximum, multipleOf
+//   - minLength, maxLength, pattern
+//   - items, prefixItems, minItems, maxItems, uniqueItems
+//   - properties, patternProperties, additionalProperties,
+//     required, minProperties, maxProperties
+//   - $ref to a JSON pointer within the schema document, e.g. "#/$defs/item"
+//
+// Other keywords are ignored. Schemas are never fetched over the network.
+//
+// The failure message lists every violation with the JSON pointer of the
+// invalid value and the location of the violated keyword in the schema.
+func MatchesJSONSchema[D ~string | ~[]byte, S ~string | ~[]byte](t testing.TB, doc D, schema S) bool {
+	t.Helper()
+	return failed(t, 1, CheckMatchesJSONSchema(doc, schema))
+}
+
+// CheckMatchesJSONSchema returns a [*Failure] if the JSON document
+// does not conform to the JSON schema, see [MatchesJSONSchema].
+func CheckMatchesJSONSchema[D ~string | ~[]byte, S ~string | ~[]byte](doc D, schema S) error {
+	docv, err := decodeJSON([]byte(doc))
+	if err != nil {
+		return &Failure{Message: fmt.Sprintf("invalid JSON: %v", err), Got: doc}
+	}
+
+	schemav, err := decodeJSON([]byte(schema))
+	if err != nil {
+		return &Failure{Message: fmt.Sprintf("invalid JSON schema: %v", err), Want: schema}
+	}
+
+	sv := &schemaValidator{root: schemav}
+	sv.validate(docv, "", schemav, "#")
+	if sv.invalid != nil {
+		return &Failure{Message: fmt.Sprintf("invalid JSON schema: %v", sv.invalid), Want: schema}
+	}
+	if len(sv.violations) > 0 {
+		return &Failure{
+			Message: "JSON does not match schema\n" + strings.Join(sv.violations, "\n"),
+			Got:     doc,
+			Want:    schema,
+		}
+	}
+	return nil
+}
+
+// schemaMaxDepth limits the depth of schema evaluation
+// to detect infinitely recursive $ref chains.
+const schemaMaxDepth = 256
+
+type schemaValidator struct {
+	root  any
+	depth int
+
+	// violations are the failures in the "instance: message (keyword)" form.
+	violations []string
+
+	// invalid is the first error found in the schema itself.
+	invalid error
+}
+
+// valid reports whether instance matches schema, without
+// recording violations.
+func (sv *schemaValidator) valid(instance any, ipath string, schema any, spath string) bool {
+	sub := &schemaValidator{root: sv.root, depth: sv.depth}
+	sub.validate(instance, ipath, schema, spath)
+	if sub.invalid != nil && sv.invalid == nil {
+		sv.invalid = sub.invalid
+	}
+	return len(sub.violations) == 0
+}
+
+func (sv *schemaValidator) violation(ipath, spath, format string, args ...any) {
+	if ipath == "" {
+		ipath = "/"
+	}
+	sv.violations = append(sv.violations, fmt.Sprintf("  %s: %s (%s)", ipath, fmt.Sprintf(format, args...), spath))
+}
+
+// validate records violations of instance at JSON pointer ipath
+// against schema at location spath.
+func (sv *schemaValidator) validate(instance any, ipath string, schema any, spath string) {
+	if sv.invalid != nil {
+		return
+	}
+
+	sv.depth++
+	defer func() { sv.depth-- }()
+	if sv.depth > schemaMaxDepth {
+		sv.invalid = fmt.Errorf("%s: maximum depth exceeded, check for recursive $ref", spath)
+		return
+	}
+
+	switch s := schema.(type) {
+	case bool:
+		if !s {
+			sv.violation(ipath, spath, "no value is allowed")
+		}
+		return
+	case map[string]any:
+		sv.validateObject(instance, ipath, s, spath)
+	default:
+		sv.invalid = fmt.Errorf("%s: schema must be an object or a boolean", spath)
+	}
+}
+
+func (sv *schemaValidator) validateObject(instance any, ipath string, s map[string]any, spath string) {
+	if ref, ok := s["$ref"].(string); ok {
+		target, err := resolveJSONPointer(sv.root, ref)
+		if err != nil {
+			sv.invalid = fmt.Errorf("%s/$ref: %v", spath, err)
+			return
+		}
+		sv.validate(instance, ipath, target, ref)
+	}
+
+	if typ, ok := s["type"]; ok {
+		types := []any{typ}
+		if list, ok := typ.([]any); ok {
+			types = list
+		}
+
+		matched := false
+		names := make([]string, 0, len(types))
+		for _, typ := range types {
+			name, _ := typ.(string)
+			names = append(names, name)
+			matched = matched || isJSONType(instance, name)
+		}
+		if !matched {
+			sv.violation(ipath, spath+"/type", "got %s, want %s", jsonTypeOf(instance), strings.Join(names, " or "))
+		}
+	}
+
+	if enum, ok := s["enum"].([]any); ok {
+		found := false
+		for _, e := range enum {
+			found = found || equalJSON(instance, e)
+		}
+		if !found {
+			sv.violation(ipath, spath+"/enum", "%s is not one of %s", jsonSnippet(instance), jsonSnippet(enum))
+		}
+	}
+
+	if c, ok := s["const"]; ok && !equalJSON(instance, c) {
+		sv.violation(ipath, spath+"/const", "got %s, want %s", jsonSnippet(instance), jsonSnippet(c))
+	}
+
+	sv.validateCombinators(instance, ipath, s, spath)
+
+	switch v := instance.(type) {
+	case json.Number:
+		sv.validateNumber(v, ipath, s, spath)
+	case string:
+		sv.validateString(v, ipath, s, spath)
+	case []any:
+		sv.validateArray(v, ipath, s, spath)
+	case map[string]any:
+		sv.validateProperties(v, ipath, s, spath)
+	}
+}
+
+func (sv *schemaValidator) validateCombinators(instance any, ipath string, s map[string]any, spath string) {
+	if all, ok := s["allOf"].([]any); ok {
+		for i, sub := range all {
+			sv.validate(instance, ipath, sub, spath+"/allOf/"+strconv.Itoa(i))
+		}
+	}
+
+	if anyOf, ok := s["anyOf"].([]any); ok {
+		matched := false
+		for i, sub := range anyOf {
+			matched = matched || sv.valid(instance, ipath, sub, spath+"/anyOf/"+strconv.Itoa(i))
+		}
+		if !matched {
+			sv.violation(ipath, spath+"/anyOf", "does not match any schema")
+		}
+	}
+
+	if oneOf, ok := s["oneOf"].([]any); ok {
+		var matched []string
+		for i, sub := range oneOf {
+			if sv.valid(instance, ipath, sub, spath+"/oneOf/"+strconv.Itoa(i)) {
+				matched = append(matched, strconv.Itoa(i))
+			}
+		}
+		switch len(matched) {
+		case 0:
+			sv.violation(ipath, spath+"/oneOf", "does not match any schema")
+		case 1:
+		default:
+			sv.violation(ipath, spath+"/oneOf", "matches more than one schema: %s", strings.Join(matched, ", "))
+		}
+	}
+
+	if not, ok := s["not"]; ok && sv.valid(instance, ipath, not, spath+"/not") {
+		sv.violation(ipath, spath+"/not", "must not match the schema")
+	}
+}
+
+func (sv *schemaValidator) validateNumber(n json.Number, ipath string, s map[string]any, spath string) {
+	v, ok := new(big.Rat).SetString(string(n))
+	if !ok {
+		return
+	}
+
+	checks := []struct {
+		keyword string
+		fail    func(cmp int) bool
+		message string
+	}{
+		{"minimum", func(c int) bool { return c < 0 }, "must be >= %s"},
+		{"maximum", func(c int) bool { return c > 0 }, "must be <= %s"},
+		{"exclusiveMinimum", func(c int) bool { return c <= 0 }, "must be > %s"},
+		{"exclusiveMaximum", func(c int) bool { return c >= 0 }, "must be < %s"},
+	}
+	for _, check := range checks {
+		limit, ok := sv.schemaNumber(s, check.keyword, spath)
+		if ok && check.fail(v.Cmp(limit)) {
+			sv.violation(ipath, spath+"/"+check.keyword, "%s "+check.message, n, s[check.keyword])
+		}
+	}
+
+	if m, ok := sv.schemaNumber(s, "multipleOf", spath); ok && m.Sign() > 0 {
+		if !new(big.Rat).Quo(v, m).IsInt() {
+			sv.violation(ipath, spath+"/multipleOf", "%s must be a multiple of %s", n, s["multipleOf"])
+		}
+	}
+}
+
+func (sv *schemaValidator) validateString(str string, ipath string, s map[string]any, spath string) {
+	length := utf8.RuneCountInString(str)
+	if limit, ok := sv.schemaInt(s, "minLength", spath); ok && length < limit {
+		sv.violation(ipath, spath+"/minLength", "length %d must be >= %d", length, limit)
+	}
+	if limit, ok := sv.schemaInt(s, "maxLength", spath); ok && length > limit {
+		sv.violation(ipath, spath+"/maxLength", "length %d must be <= %d", length, limit)
+	}
+
+	if pattern, ok := s["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			sv.invalid = fmt.Errorf("%s/pattern: %v", spath, err)
+			return
+		}
+		if !re.MatchString(str) {
+			sv.violation(ipath, spath+"/pattern", "%q does not match %q", str, pattern)
+		}
+	}
+}
+
+func (sv *schemaValidator) validateArray(arr []any, ipath string, s map[string]any, spath string) {
+	if limit, ok := sv.schemaInt(s, "minItems", spath); ok && len(arr) < limit {
+		sv.violation(ipath, spath+"/minItems", "length %d must be >= %d", len(arr), limit)
+	}
+	if limit, ok := sv.schemaInt(s, "maxItems", spath); ok && len(arr) > limit {
+		sv.violation(ipath, spath+"/maxItems", "length %d must be <= %d", len(arr), limit)
+	}
+
+	if unique, _ := s["uniqueItems"].(bool); unique {
+	unique:
+		for i := range arr {
+			for j := i + 1; j < len(arr); j++ {
+				if equalJSON(arr[i], arr[j]) {
+					sv.violation(ipath, spath+"/uniqueItems", "items %d and %d are equal", i, j)
+					break unique
+				}
+			}
+		}
+	}
+
+	prefix, _ := s["prefixItems"].([]any)
+	for i := 0; i < len(prefix) && i < len(arr); i++ {
+		sv.validate(arr[i], ipath+"/"+strconv.Itoa(i), prefix[i], spath+"/prefixItems/"+strconv.Itoa(i))
+	}
+
+	if items, ok := s["items"]; ok {
+		for i := len(prefix); i < len(arr); i++ {
+			sv.validate(arr[i], ipath+"/"+strconv.Itoa(i), items, spath+"/items")
+		}
+	}
+}
+
+func (sv *schemaValidator) validateProperties(obj map[string]any, ipath string, s map[string]any, spath string) {
+	if limit, ok := sv.schemaInt(s, "minProperties", spath); ok && len(obj) < limit {
+		sv.violation(ipath, spath+"/minProperties", "%d properties, want at least %d", len(obj), limit)
+	}
+	if limit, ok := sv.schemaInt(s, "maxProperties", spath); ok && len(obj) > limit {
+		sv.violation(ipath, spath+"/maxProperties", "%d properties, want at most %d", len(obj), limit)
+	}
+
+	if required, ok := s["required"].([]any); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				if _, ok := obj[name]; !ok {
+					sv.violation(ipath, spath+"/required", "missing property %q", name)
+				}
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	// compile patterns once, in a stable order of violations
+	type patternSchema struct {
+		pattern string
+		re      *regexp.Regexp
+		sub     any
+	}
+	patternSubs, _ := s["patternProperties"].(map[string]any)
+	patterns := make([]patternSchema, 0, len(patternSubs))
+	for pattern, sub := range patternSubs {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			sv.invalid = fmt.Errorf("%s/patternProperties: %v", spath, err)
+			return
+		}
+		patterns = append(patterns, patternSchema{pattern, re, sub})
+	}
+	sort.Slice(patterns, func(i, j int) bool { return patterns[i].pattern < patterns[j].pattern })
+
+	props, _ := s["properties"].(map[string]any)
+	additional, hasAdditional := s["additionalProperties"]
+	for _, k := range keys {
+		kpath := ipath + "/" + escapeJSONPointer(k)
+
+		evaluated := false
+		if sub, ok := props[k]; ok {
+			evaluated = true
+			sv.validate(obj[k], kpath, sub, spath+"/properties/"+escapeJSONPointer(k))
+		}
+
+		for _, p := range patterns {
+			if p.re.MatchString(k) {
+				evaluated = true
+				sv.validate(obj[k], kpath, p.sub, spath+"/patternProperties/"+escapeJSONPointer(p.pattern))
+			}
+		}
+
+		if !evaluated && hasAdditional {
+			if additional == false {
+				sv.violation(kpath, spath+"/additionalProperties", "property %q is not allowed", k)
+				continue
+			}
+			sv.validate(obj[k], kpath, additional, spath+"/additionalProperties")
+		}
+	}
+}
+
+func (sv *schemaValidator) schemaNumber(s map[string]any, keyword, spath string) (*big.Rat, bool) {
+	v, ok := s[keyword]
+	if !ok {
+		return nil, false
+	}
+
+	n, _ := v.(json.Number)
+	r, ok := new(big.Rat).SetString(string(n))
+	if !ok {
+		sv.invalid = fmt.Errorf("%s/%s: must be a number", spath, keyword)
+		return nil, false
+	}
+	return r, true
+}
+
+func (sv *schemaValidator) schemaInt(s map[string]any, keyword, spath string) (int, bool) {
+	r, ok := sv.schemaNumber(s, keyword, spath)
+	if !ok {
+		return 0, false
+	}
+	if !r.IsInt() || !r.Num().IsInt64() {
+		sv.invalid = fmt.Errorf("%s/%s: must be an integer", spath, keyword)
+		return 0, false
+	}
+	return int(r.Num().Int64()), true
+}
+
+// resolveJSONPointer resolves a reference like "#/$defs/item" within root.
+func resolveJSONPointer(root any, ref string) (any, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("only references within the schema are supported, got %q", ref)
+	}
+
+	v := root
+	pointer := strings.TrimPrefix(ref, "#")
+	if pointer == "" {
+		return v, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("unsupported reference %q", ref)
+	}
+
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch node := v.(type) {
+		case map[string]any:
+			next, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("reference %q not found", ref)
+			}
+			v = next
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, fmt.Errorf("reference %q not found", ref)
+			}
+			v = node[i]
+		default:
+			return nil, fmt.Errorf("reference %q not found", ref)
+		}
+	}
+	return v, nil
+}
+
+func escapeJSONPointer(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+func isJSONType(v any, typ string) bool {
+	switch typ {
+	case "integer":
+		n, ok := v.(json.Number)
+		if !ok {
+			return false
+		}
+		r, ok := new(big.Rat).SetString(string(n))
+		return ok && r.IsInt()
+	case "number":
+		_, ok := v.(json.Number)
+		return ok
+	default:
+		return jsonTypeOf(v) == typ
+	}
+}
+
+func jsonTypeOf(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
@@ -0,0 +1,133 @@
+package assert
+
+import (
+	"testing"
+)
+
+const testSchema = `{
+	"$defs": {
+		"item": {
+			"type": "object",
+			"required": ["id", "price"],
+			"properties": {
+				"id": {"type": "integer", "minimum": 1},
+				"price": {"type": "number", "exclusiveMinimum": 0, "multipleOf": 0.01},
+				"sku": {"type": "string", "pattern": "^[A-Z]{3}-[0-9]+$"}
+			},
+			"additionalProperties": false
+		}
+	},
+	"type": "object",
+	"required": ["status", "items"],
+	"properties": {
+		"status": {"enum": ["ok", "partial"]},
+		"items": {"type": "array", "items": {"$ref": "#/$defs/item"}, "minItems": 1, "uniqueItems": true},
+		"next": {"anyOf": [{"type": "null"}, {"type": "string", "minLength": 1}]},
+		"count": {"oneOf": [{"type": "integer"}, {"type": "number", "maximum": 10}]},
+		"tags": {"type": "array", "prefixItems": [{"const": "main"}], "items": {"type": "string"}}
+	}
+}`
+
+func TestMatchesJSONSchema(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		fail string
+	}{
+		{
+			name: "valid",
+			doc:  `{"status": "ok", "items": [{"id": 1, "price": 12.5, "sku": "ABC-1"}], "next": null, "count": 20, "tags": ["main", "x"]}`,
+		},
+		{
+			name: "wrong type",
+			doc:  `[]`,
+			fail: "/: got array, want object (#/type)",
+		},
+		{
+			name: "every violation",
+			doc:  `{"status": "bad", "items": [{"id": 0, "price": 1.005, "sku": "abc", "x": 1}]}`,
+			fail: "JSON does not match schema\n" +
+				`  /items/0/id: 0 must be >= 1 (#/$defs/item/properties/id/minimum)` + "\n" +
+				`  /items/0/price: 1.005 must be a multiple of 0.01 (#/$defs/item/properties/price/multipleOf)` + "\n" +
+				`  /items/0/sku: "abc" does not match "^[A-Z]{3}-[0-9]+$" (#/$defs/item/properties/sku/pattern)` + "\n" +
+				`  /items/0/x: property "x" is not allowed (#/$defs/item/additionalProperties)` + "\n" +
+				`  /status: "bad" is not one of ["ok","partial"] (#/properties/status/enum)`,
+		},
+		{
+			name: "required",
+			doc:  `{"items": [{"id": 1}]}`,
+			fail: `/: missing property "status" (#/required)`,
+		},
+		{
+			name: "any of",
+			doc:  `{"status": "ok", "items": [{"id": 1, "price": 1}], "next": ""}`,
+			fail: "/next: does not match any schema (#/properties/next/anyOf)",
+		},
+		{
+			name: "one of",
+			doc:  `{"status": "ok", "items": [{"id": 1, "price": 1}], "count": 2}`,
+			fail: "/count: matches more than one schema: 0, 1 (#/properties/count/oneOf)",
+		},
+		{
+			name: "unique items",
+			doc:  `{"status": "ok", "items": [{"id": 1, "price": 1}, {"price": 1.0, "id": 1}]}`,
+			fail: "/items: items 0 and 1 are equal (#/properties/items/uniqueItems)",
+		},
+		{
+			name: "prefix items",
+			doc:  `{"status": "ok", "items": [{"id": 1, "price": 1}], "tags": ["other", 1]}`,
+			fail: `/tags/0: got "other", want "main" (#/properties/tags/prefixItems/0/const)` + "\n" +
+				"  /tags/1: got number, want string (#/properties/tags/items/type)",
+		},
+		{
+			name: "invalid JSON",
+			doc:  `{`,
+			fail: "invalid JSON",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atb := &assertTB{TB: t}
+			MatchesJSONSchema(atb, tt.doc, testSchema)
+			atb.check(t, tt.fail)
+		})
+	}
+}
+
+func TestMatchesJSONSchemaInvalid(t *testing.T) {
+	tests := []struct {
+		schema string
+		fail   string
+	}{
+		{schema: `{`, fail: "invalid JSON schema"},
+		{schema: `1`, fail: "invalid JSON schema: #: schema must be an object or a boolean"},
+		{schema: `{"$ref": "http://example.com/schema"}`, fail: "only references within the schema are supported"},
+		{schema: `{"$ref": "#/$defs/missing"}`, fail: `reference "#/$defs/missing" not found`},
+		{schema: `{"$ref": "#"}`, fail: "maximum depth exceeded"},
+		{schema: `{"pattern": "("}`, fail: "#/pattern: error parsing regexp"},
+		{schema: `{"minLength": 1.5}`, fail: "#/minLength: must be an integer"},
+		{schema: `false`, fail: "/: no value is allowed (#)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.schema, func(t *testing.T) {
+			atb := &assertTB{TB: t}
+			MatchesJSONSchema(atb, `"a"`, tt.schema)
+			atb.check(t, tt.fail)
+		})
+	}
+}
+
+func TestMatchesJSONSchemaPatternOrder(t *testing.T) {
+	schema := `{"patternProperties": {"^a": {"type": "string"}, "b$": {"minLength": 2}, "^ab": {"const": "x"}}}`
+
+	// map iteration order must not change the order of violations
+	for range 20 {
+		atb := &assertTB{TB: t}
+		MatchesJSONSchema(atb, `{"ab": 1}`, schema)
+		atb.fail(t, "JSON does not match schema\n"+
+			`  /ab: got number, want string (#/patternProperties/^a/type)`+"\n"+
+			`  /ab: got 1, want "x" (#/patternProperties/^ab/const)`)
+	}
+}
@@ -0,0 +1,184 @@
+package assert
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Diff styles selected with options or the ASSERT_DIFF environment variable.
+const (
+	diffUnified    = "unified"
+	diffSideBySide = "side-by-side"
+	diffPaths      = "paths"
+)
+
+const (
+	// defaultDiffWidth is the width of side-by-side diffs
+	// when the terminal width is unknown.
+	defaultDiffWidth = 120
+
+	// minDiffWidth is the minimum width of side-by-side diffs,
+	// below which the unified diff is used.
+	minDiffWidth = 40
+
+	// maxSideBySideDepth is the maximum nesting of values rendered
+	// side by side, deeper values use the unified diff.
+	maxSideBySideDepth = 10
+)
+
+// SideBySide returns an EqualOption that renders differences
+// with got and want values in two columns, marking differing lines.
+//
+// Lines are compared as text, so values compared with options ignoring
+// fields or relaxing the comparison, like [SkipFieldNames] or [Tolerance],
+// fall back to the default unified diff, as do very deep values.
+//
+// Side-by-side diffs can be enabled for all assertions by setting
+// the ASSERT_DIFF environment variable to "side-by-side".
+func SideBySide() EqualOption {
+	return func(o *equaler) {
+		o.diffStyle = diffSideBySide
+	}
+}
+
+// DiffWidth returns an EqualOption that sets the width of side-by-side diffs.
+//
+// By default the width of the terminal is used,
+// or the ASSERT_DIFF_WIDTH environment variable if set.
+func DiffWidth(width int) EqualOption {
+	return func(o *equaler) {
+		o.diffWidth = width
+	}
+}
+
+// style returns the diff style to use.
+func (o *equaler) style() string {
+	if o.diffStyle != "" {
+		return o.diffStyle
+	}
+	if style := os.Getenv("ASSERT_DIFF"); style != "" {
+		return style
+	}
+	return diffUnified
+}
+
+// relaxed reports whether options make values equal
+// even if their Go syntax differs.
+func (o *equaler) relaxed() bool {
+	return o.ignoreUnexported || o.skipEmptyFields || o.skipZeroFields ||
+		len(o.skipFieldNames) > 0 || o.tolerance > 0 || o.equateEmpty ||
+		o.timeMargin > 0 || len(o.sortSlices) > 0
+}
+
+// width returns the width of side-by-side diffs.
+func (o *equaler) width() int {
+	if o.diffWidth > 0 {
+		return o.diffWidth
+	}
+	if w, err := strconv.Atoi(os.Getenv("ASSERT_DIFF_WIDTH")); err == nil && w > 0 {
+		return w
+	}
+	if w, ok := terminalWidth(os.Stdout); ok && w > 0 {
+		return w
+	}
+	return defaultDiffWidth
+}
+
+// sideBySide renders got and want in two columns.
+// ok is false if the values are too deep, too different
+// to be aligned or the width too small.
+func sideBySide(got, want string, width int, color bool) (string, bool) {
+	if width < minDiffWidth {
+		return "", false
+	}
+
+	gotl := expandTabs(strings.Split(got, "\n"))
+	wantl := expandTabs(strings.Split(want, "\n"))
+	if max(maxIndent(gotl), maxIndent(wantl)) > maxSideBySideDepth {
+		return "", false
+	}
+
+	edits, ok := editScript(gotl, wantl)
+	if !ok {
+		return "", false
+	}
+
+	col := (width - 3) / 2
+	var sb strings.Builder
+	row := func(left, mark, right string) {
+		left, right = truncate(left, col), truncate(right, col)
+		pad := strings.Repeat(" ", col-utf8.RuneCountInString(left))
+		if color {
+			switch mark {
+			case "|":
+				left, right = colorRed+left+colorReset, colorGreen+right+colorReset
+			case "<":
+				left = colorRed + left + colorReset
+			case ">":
+				right = colorGreen + right + colorReset
+			}
+		}
+		sb.WriteString(strings.TrimRight(left+pad+" "+mark+" "+right, " "))
+		sb.WriteString("\n")
+	}
+
+	row("got", " ", "want")
+	row(strings.Repeat("-", col), " ", strings.Repeat("-", col))
+
+	var dels, ins []string
+	flush := func() {
+		for i := 0; i < max(len(dels), len(ins)); i++ {
+			switch {
+			case i >= len(dels):
+				row("", ">", ins[i])
+			case i >= len(ins):
+				row(dels[i], "<", "")
+			default:
+				row(dels[i], "|", ins[i])
+			}
+		}
+		dels, ins = dels[:0], ins[:0]
+	}
+
+	for _, e := range edits {
+		switch e.op {
+		case '-':
+			dels = append(dels, gotl[e.a])
+		case '+':
+			ins = append(ins, wantl[e.b])
+		default:
+			flush()
+			row(gotl[e.a], " ", wantl[e.b])
+		}
+	}
+	flush()
+	return sb.String(), true
+}
+
+// expandTabs replaces indentation tabs with two spaces.
+func expandTabs(lines []string) []string {
+	for i, line := range lines {
+		lines[i] = strings.ReplaceAll(line, "\t", "  ")
+	}
+	return lines
+}
+
+// maxIndent returns the maximum indentation level of lines.
+func maxIndent(lines []string) int {
+	depth := 0
+	for _, line := range lines {
+		depth = max(depth, (len(line)-len(strings.TrimLeft(line, " ")))/2)
+	}
+	return depth
+}
+
+// truncate shortens s to at most width runes.
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	r := []rune(s)
+	return string(r[:width-3]) + "..."
+}
@@ -0,0 +1,58 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEqualSideBySide(t *testing.T) {
+	type T struct {
+		A int
+		B []string
+	}
+
+	got := T{A: 1, B: []string{"a", "b"}}
+	want := T{A: 2, B: []string{"a"}}
+
+	atb := &assertTB{TB: t}
+	Equal(atb, got, want, SideBySide(), DiffWidth(43))
+	atb.fail(t, "expected equal\ndiff:\n"+
+		"got                    want\n"+
+		"--------------------   --------------------\n"+
+		"assert.T{              assert.T{\n"+
+		"  A: 1,              |   A: 2,\n"+
+		"  B: []string{           B: []string{\n"+
+		"    \"a\",                   \"a\",\n"+
+		"    \"b\",             <\n"+
+		"  },                     },\n"+
+		"}                      }\n")
+
+	// the environment variable selects the style
+	t.Setenv("ASSERT_DIFF", "side-by-side")
+	t.Setenv("ASSERT_DIFF_WIDTH", "43")
+	atb = &assertTB{TB: t}
+	Equal(atb, got, want)
+	atb.fail(t, "  A: 1,              |   A: 2,")
+
+	// ignored fields would be shown as differing lines
+	atb = &assertTB{TB: t}
+	Equal(atb, got, want, SkipFieldNames("B"))
+	atb.fail(t, "... // 1 ignored field")
+	False(t, strings.Contains(atb.message, "|"))
+
+	// too narrow for two columns
+	atb = &assertTB{TB: t}
+	Equal(atb, got, want, DiffWidth(20))
+	atb.fail(t, "A: 1,")
+	False(t, strings.Contains(atb.message, "|"))
+}
+
+func TestSideBySideDepth(t *testing.T) {
+	deep := strings.Repeat("\t", maxSideBySideDepth+1) + "x"
+	_, ok := sideBySide(deep, "y", 80, false)
+	False(t, ok)
+
+	out, ok := sideBySide("abcdefghijklmnopqrstuvwxyz", "z", 43, false)
+	True(t, ok)
+	True(t, strings.Contains(out, "abcdefghijklmnopq... | z"))
+}
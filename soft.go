@@ -0,0 +1,40 @@
+package assert
+
+import (
+	"testing"
+)
+
+// Soft returns a testing.TB that reports failures of assertions
+// without stopping the test. Fatal and Fatalf are turned into Error
+// and Errorf, and FailNow marks the test as failed and returns.
+//
+// Assertions return false when they fail, so dependent checks
+// can be guarded:
+//
+//	st := assert.Soft(t)
+//	assert.Equal(st, user.Name, "alice")
+//	assert.Equal(st, user.Email, "alice@example.com")
+//	if assert.Len(st, user.Roles, 1) {
+//		assert.Equal(st, user.Roles[0], "admin")
+//	}
+func Soft(t testing.TB) testing.TB {
+	return &softTB{TB: t}
+}
+
+type softTB struct {
+	testing.TB
+}
+
+func (s *softTB) Fatal(args ...any) {
+	s.TB.Helper()
+	s.TB.Error(args...)
+}
+
+func (s *softTB) Fatalf(format string, args ...any) {
+	s.TB.Helper()
+	s.TB.Errorf(format, args...)
+}
+
+func (s *softTB) FailNow() {
+	s.TB.Fail()
+}
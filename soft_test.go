@@ -0,0 +1,75 @@
+package assert
+
+import (
+	"fmt"
+	"testing"
+)
+
+// errorTB records messages reported with Errorf.
+type errorTB struct {
+	testing.TB
+	errors []string
+	failed bool
+}
+
+func (etb *errorTB) Helper() {}
+
+func (etb *errorTB) Errorf(format string, args ...any) {
+	etb.errors = append(etb.errors, fmt.Sprintf(format, args...))
+}
+
+func (etb *errorTB) Error(args ...any) {
+	etb.errors = append(etb.errors, fmt.Sprint(args...))
+}
+
+func (etb *errorTB) Fail() {
+	etb.failed = true
+}
+
+func TestSoft(t *testing.T) {
+	etb := &errorTB{TB: t}
+	st := Soft(etb)
+
+	got := []int{1, 2}
+	Equal(t, Equal(st, got[0], 2), false)
+	Equal(t, True(st, got[1] == 2), true)
+	if Len(st, got, 3) {
+		t.Fatal("Len passed")
+	}
+	Equal(t, etb.errors, []string{
+		"got[0]: expected equal\n" + diffValue(t, 1, 2),
+		"got: expected length 3, got 2",
+	})
+
+	st.Fatal("fatal")
+	st.FailNow()
+	Equal(t, etb.errors[2], "fatal")
+	True(t, etb.failed)
+}
+
+func TestAssertionResult(t *testing.T) {
+	tests := []struct {
+		name   string
+		assert func(t testing.TB) bool
+		want   bool
+	}{
+		{"Equal", func(t testing.TB) bool { return Equal(t, 1, 1) }, true},
+		{"NotEqual", func(t testing.TB) bool { return NotEqual(t, 1, 1) }, false},
+		{"ErrorContains", func(t testing.TB) bool { return ErrorContains(t, fmt.Errorf("a"), "b") }, false},
+		{"ErrorWant", func(t testing.TB) bool { return ErrorWant(t, false, nil) }, true},
+		{"Panic", func(t testing.TB) bool { return Panic(t, func() { panic(0) }) }, true},
+		{"Panic no panic", func(t testing.TB) bool { return Panic(t, func() {}) }, false},
+		{"NotPanic", func(t testing.TB) bool { return NotPanic(t, func() {}) }, true},
+		{"NotPanic panic", func(t testing.TB) bool { return NotPanic(t, func() { panic(0) }) }, false},
+		{"JSONEq", func(t testing.TB) bool { return JSONEq(t, `{"a":1}`, `{"a":2}`) }, false},
+		{"JSONPathExists", func(t testing.TB) bool { return JSONPathExists(t, `{"a":1}`, "$.a") }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atb := &assertTB{TB: t}
+			Equal(t, tt.assert(atb), tt.want)
+			Equal(t, atb.failed, !tt.want)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package assert
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fatalf fails the test with the message prefixed by the source expression
+// of the argument at index arg of the assertion call, e.g. for
+// assert.True(t, user.IsActive()) the message starts with "user.IsActive(): ".
+// The prefix is omitted if the source is not available or the argument is
+// a literal.
+func fatalf(t testing.TB, arg int, format string, args ...any) {
+	t.Helper()
+	msg := fmt.Sprintf(format, args...)
+	// fluent assertions are labeled by labelTB
+	if _, ok := t.(*labelTB); ok {
+		t.Fatalf("%s", msg)
+		return
+	}
+	if expr, ok := argExpr(arg); ok {
+		msg = expr + ": " + msg
+	}
+	t.Fatalf("%s", msg)
+}
+
+// callSite returns the location of the first caller outside of the non-test
+// files of this package and the name of the function of this package it called.
+func callSite() (fn, file string, line int, ok bool) {
+	_, self, _, ok := runtime.Caller(0)
+	if !ok {
+		return "", "", 0, false
+	}
+	dir := filepath.Dir(self)
+
+	pc := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pc[:runtime.Callers(2, pc)])
+	for {
+		frame, more := frames.Next()
+		if filepath.Dir(frame.File) != dir || strings.HasSuffix(frame.File, "_test.go") {
+			return fn, frame.File, frame.Line, frame.File != ""
+		}
+		fn = funcName(frame.Function)
+		if !more {
+			return "", "", 0, false
+		}
+	}
+}
+
+// funcName returns the name of a function or method without its package
+// and type parameters, e.g. "Equal" for "github.com/krhubert/assert.Equal[...]".
+// Closures are named after the enclosing function.
+func funcName(name string) string {
+	name, _, _ = strings.Cut(name, "[")
+	parts := strings.Split(name, ".")
+	for len(parts) > 1 && isClosureName(parts[len(parts)-1]) {
+		parts = parts[:len(parts)-1]
+	}
+	return parts[len(parts)-1]
+}
+
+// isClosureName reports whether name is a generated name
+// of a closure, like "func1" or "2".
+func isClosureName(name string) bool {
+	name = strings.TrimPrefix(name, "func")
+	return name != "" && strings.Trim(name, "0123456789") == ""
+}
+
+// sourceFile is a parsed source file.
+type sourceFile struct {
+	fset *token.FileSet
+	file *ast.File
+	src  []byte
+}
+
+// sourceFiles caches parsed source files by path,
+// holding nil for files that cannot be parsed.
+var sourceFiles sync.Map
+
+func parseSource(path string) *sourceFile {
+	if sf, ok := sourceFiles.Load(path); ok {
+		return sf.(*sourceFile)
+	}
+
+	var sf *sourceFile
+	if src, err := os.ReadFile(path); err == nil {
+		fset := token.NewFileSet()
+		if file, err := parser.ParseFile(fset, path, src, 0); err == nil {
+			sf = &sourceFile{fset: fset, file: file, src: src}
+		}
+	}
+	sourceFiles.Store(path, sf)
+	return sf
+}
+
+// argExpr returns the source of the argument at index arg of the
+// assertion call that is being executed.
+func argExpr(arg int) (string, bool) {
+	fn, file, line, ok := callSite()
+	if !ok || fn == "" {
+		return "", false
+	}
+	return callArg(fn, file, line, arg)
+}
+
+// callArg returns the source of the argument at index arg
+// of the call of the function fn at the line of the file.
+func callArg(fn, file string, line, arg int) (string, bool) {
+	sf := parseSource(file)
+	if sf == nil {
+		return "", false
+	}
+
+	// find the innermost call of the function spanning the line
+	var calls []*ast.CallExpr
+	ast.Inspect(sf.file, func(n ast.Node) bool {
+		c, ok := n.(*ast.CallExpr)
+		if !ok || calleeName(c.Fun) != fn {
+			return true
+		}
+		if sf.fset.Position(c.Pos()).Line <= line && line <= sf.fset.Position(c.End()).Line {
+			calls = append(calls, c)
+		}
+		return true
+	})
+	if len(calls) == 0 {
+		return "", false
+	}
+
+	// calls are found in the order of their positions, so a call
+	// is nested in all previous ones unless the line is ambiguous
+	call := calls[len(calls)-1]
+	for _, c := range calls {
+		if c.Pos() > call.Pos() || c.End() < call.End() {
+			return "", false
+		}
+	}
+	if arg >= len(call.Args) {
+		return "", false
+	}
+
+	expr := call.Args[arg]
+	switch e := expr.(type) {
+	case *ast.BasicLit, *ast.FuncLit:
+		return "", false
+	case *ast.Ident:
+		if e.Name == "nil" || e.Name == "true" || e.Name == "false" {
+			return "", false
+		}
+	}
+
+	start, end := sf.fset.Position(expr.Pos()).Offset, sf.fset.Position(expr.End()).Offset
+	return strings.Join(strings.Fields(string(sf.src[start:end])), " "), true
+}
+
+// calleeName returns the name of the called function or method.
+func calleeName(fun ast.Expr) string {
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return f.Name
+	case *ast.SelectorExpr:
+		return f.Sel.Name
+	case *ast.IndexExpr:
+		return calleeName(f.X)
+	case *ast.IndexListExpr:
+		return calleeName(f.X)
+	default:
+		return ""
+	}
+}
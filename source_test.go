@@ -0,0 +1,63 @@
+package assert
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type sourceUser struct {
+	active bool
+}
+
+func (u sourceUser) IsActive() bool { return u.active }
+
+func TestSourceExpression(t *testing.T) {
+	user := sourceUser{}
+
+	atb := &assertTB{TB: t}
+	True(atb, user.IsActive())
+	atb.fail(t, "user.IsActive(): expected true, got false")
+
+	atb = &assertTB{TB: t}
+	Equal(atb,
+		len([]int{1, 2}),
+		3,
+	)
+	atb.fail(t, "len([]int{1, 2}): expected equal")
+
+	atb = &assertTB{TB: t}
+	ErrorWant(atb, false, errors.New("boom"))
+	atb.fail(t, `errors.New("boom"): unexpected error: boom`)
+
+	// calls on the same line are not told apart
+	a, b := 1, 1
+	atb = &assertTB{TB: t}
+	_ = Equal(atb, b, 1) && Equal(atb, a, 2)
+	True(t, strings.HasPrefix(atb.message, "expected equal\n"))
+
+	// literals are not repeated
+	atb = &assertTB{TB: t}
+	False(atb, true)
+	Equal(t, atb.message, "expected false, got true")
+}
+
+func TestFuncName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "github.com/krhubert/assert.True", want: "True"},
+		{name: "github.com/krhubert/assert.Equal[...]", want: "Equal"},
+		{name: "github.com/krhubert/assert.ErrorContains.func1", want: "ErrorContains"},
+		{name: "github.com/krhubert/assert.(*Suite).Run.func2.1", want: "Run"},
+	}
+	for _, tt := range tests {
+		Equal(t, funcName(tt.name), tt.want)
+	}
+}
+
+func TestParseSourceUnavailable(t *testing.T) {
+	// e.g. binaries built with -trimpath
+	Nil(t, parseSource("github.com/krhubert/assert/missing_test.go"))
+}
@@ -0,0 +1,13 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package assert
+
+import (
+	"os"
+)
+
+// terminalWidth returns the width of the terminal attached to f.
+// Terminals are not detected on this platform, so ok is always false.
+func terminalWidth(f *os.File) (width int, ok bool) {
+	return 0, false
+}
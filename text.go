@@ -0,0 +1,319 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// defaultContextLines is the default number of unchanged
+// lines shown around changes in unified diffs.
+const defaultContextLines = 3
+
+// ANSI escape sequences highlighting changes within a line.
+const (
+	colorReverse    = "\x1b[7m"
+	colorReverseOff = "\x1b[27m"
+)
+
+// ContextLines returns an EqualOption that sets the number of unchanged
+// lines shown around changes in diffs of multi-line strings.
+//
+// Multi-line strings, including []byte holding valid UTF-8 text,
+// are rendered as unified diffs, also when they are nested in other values.
+func ContextLines(n int) EqualOption {
+	return func(o *equaler) {
+		o.contextLines = &n
+	}
+}
+
+// context returns the number of context lines of unified diffs.
+func (o *equaler) context() int {
+	if o.contextLines != nil {
+		return max(*o.contextLines, 0)
+	}
+	return defaultContextLines
+}
+
+// textValue returns the text held by v
+// if it is a string or a []byte with valid UTF-8.
+func textValue(v reflect.Value) (string, bool) {
+	if !v.IsValid() {
+		return "", false
+	}
+
+	switch {
+	case v.Kind() == reflect.String:
+		return v.String(), true
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		b := v.Bytes()
+		return string(b), utf8.Valid(b)
+	default:
+		return "", false
+	}
+}
+
+// multilineText returns texts held by got and want
+// if at least one of them spans multiple lines.
+func multilineText(vx, vy reflect.Value) (got, want string, ok bool) {
+	got, gok := textValue(vx)
+	want, wok := textValue(vy)
+	if !gok || !wok || (!strings.Contains(got, "\n") && !strings.Contains(want, "\n")) {
+		return "", "", false
+	}
+	return got, want, true
+}
+
+// textPrefix returns the shortest prefix of p
+// pointing to a multi-line text.
+func textPrefix(p cmp.Path) (cmp.Path, bool) {
+	for i := 1; i <= len(p); i++ {
+		if _, _, ok := multilineText(p.Index(i - 1).Values()); ok {
+			return p[:i], true
+		}
+	}
+	return nil, false
+}
+
+// unifiedDiff returns a line-based diff of got and want with hunk
+// headers and n lines of context. Changes within lines are
+// highlighted with colors or marked with "^" in "?" lines.
+// Texts too different to be aligned are rendered by cmp.Diff.
+func unifiedDiff(got, want string, n int, color bool) string {
+	return unifiedDiffAt(got, want, 0, n, color)
+}
+
+// unifiedDiffAt returns the unified diff of texts
+// starting at the line with the given index.
+func unifiedDiffAt(got, want string, line, n int, color bool) string {
+	gotl, wantl := splitLines(got), splitLines(want)
+	edits, ok := editScript(gotl, wantl)
+	if !ok {
+		diff := cmp.Diff(got, want)
+		if color {
+			diff = colorizeDiff(diff)
+		}
+		return diff
+	}
+
+	var sb strings.Builder
+	for _, h := range hunks(edits, n) {
+		header := fmt.Sprintf("@@ -%s +%s @@", hunkRange(h, edits, 'a', line), hunkRange(h, edits, 'b', line))
+		if color {
+			header = colorDim + header + colorReset
+		}
+		sb.WriteString(header)
+		sb.WriteString("\n")
+
+		var dels, ins []string
+		flush := func() {
+			for i, line := range dels {
+				var pair string
+				if i < len(ins) {
+					pair = ins[i]
+				}
+				writeLine(&sb, '-', line, pair, i < len(ins), color)
+			}
+			for i, line := range ins {
+				var pair string
+				if i < len(dels) {
+					pair = dels[i]
+				}
+				writeLine(&sb, '+', line, pair, i < len(dels), color)
+			}
+			dels, ins = nil, nil
+		}
+
+		for _, e := range edits[h[0]:h[1]] {
+			switch e.op {
+			case '-':
+				dels = append(dels, gotl[e.a])
+			case '+':
+				ins = append(ins, wantl[e.b])
+			default:
+				flush()
+				line := " " + gotl[e.a]
+				if color {
+					line = colorDim + line + colorReset
+				}
+				sb.WriteString(line)
+				sb.WriteString("\n")
+			}
+		}
+		flush()
+	}
+
+	if gotNL, wantNL := strings.HasSuffix(got, "\n"), strings.HasSuffix(want, "\n"); gotNL != wantNL {
+		side := "got"
+		if gotNL {
+			side = "want"
+		}
+		sb.WriteString("\\ no newline at end of " + side + "\n")
+	}
+	return sb.String()
+}
+
+// splitLines splits s into lines, where a trailing
+// line terminator does not start a new line.
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// hunks groups edits into [start, end) ranges with n lines of context.
+func hunks(edits []edit, n int) [][2]int {
+	var out [][2]int
+	for i := 0; i < len(edits); i++ {
+		if edits[i].op == ' ' {
+			continue
+		}
+
+		start := max(i-n, 0)
+		end := i
+		// extend the hunk while changes are at most 2n lines apart
+		for j := i; j < len(edits); j++ {
+			if edits[j].op != ' ' {
+				end = j + 1
+			} else if j-end >= 2*n {
+				break
+			}
+		}
+		end = min(end+n, len(edits))
+
+		if len(out) > 0 && out[len(out)-1][1] >= start {
+			out[len(out)-1][1] = end
+		} else {
+			out = append(out, [2]int{start, end})
+		}
+		i = end - 1
+	}
+	return out
+}
+
+// hunkRange returns the "start,count" range of the hunk in the got
+// (side 'a') or want (side 'b') lines, where edits start at line.
+func hunkRange(h [2]int, edits []edit, side byte, line int) string {
+	start, count := -1, 0
+	for _, e := range edits[h[0]:h[1]] {
+		i := e.a
+		if side == 'b' {
+			i = e.b
+		}
+		if i < 0 {
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+		count++
+	}
+
+	if start < 0 {
+		// empty range starts after the preceding line
+		for k := h[0] - 1; k >= 0; k-- {
+			i := edits[k].a
+			if side == 'b' {
+				i = edits[k].b
+			}
+			if i >= 0 {
+				start = i + 1
+				break
+			}
+		}
+		return fmt.Sprintf("%d,0", line+max(start, 0))
+	}
+	return fmt.Sprintf("%d,%d", line+start+1, count)
+}
+
+// writeLine writes a changed line. If the line is paired with a line
+// on the other side, the runes that differ are highlighted.
+func writeLine(sb *strings.Builder, op byte, line, pair string, paired bool, color bool) {
+	var changed []bool
+	if paired {
+		changed = changedRunes(line, pair, op)
+	}
+
+	if !color {
+		sb.WriteByte(op)
+		sb.WriteString(line)
+		sb.WriteString("\n")
+		if marker := changeMarker(line, changed); marker != "" {
+			sb.WriteString("?")
+			sb.WriteString(marker)
+			sb.WriteString("\n")
+		}
+		return
+	}
+
+	if op == '-' {
+		sb.WriteString(colorRed)
+	} else {
+		sb.WriteString(colorGreen)
+	}
+	sb.WriteByte(op)
+	highlighted := false
+	for i, r := range []rune(line) {
+		if on := i < len(changed) && changed[i]; on != highlighted {
+			if on {
+				sb.WriteString(colorReverse)
+			} else {
+				sb.WriteString(colorReverseOff)
+			}
+			highlighted = on
+		}
+		sb.WriteRune(r)
+	}
+	sb.WriteString(colorReset)
+	sb.WriteString("\n")
+}
+
+// changedRunes reports for each rune of line whether it
+// is not present in the other line. op tells if line is got ('-') or want ('+').
+// It returns nil if the lines are too different to be aligned.
+func changedRunes(line, other string, op byte) []bool {
+	lr, or := []rune(line), []rune(other)
+	a, b := lr, or
+	if op == '+' {
+		a, b = or, lr
+	}
+
+	edits, ok := editScript(a, b)
+	if !ok {
+		return nil
+	}
+
+	changed := make([]bool, len(lr))
+	for _, e := range edits {
+		switch {
+		case op == '-' && e.op == '-':
+			changed[e.a] = true
+		case op == '+' && e.op == '+':
+			changed[e.b] = true
+		}
+	}
+	return changed
+}
+
+// changeMarker returns a line with "^" under changed runes, keeping
+// tabs to stay aligned, or an empty string if nothing changed.
+func changeMarker(line string, changed []bool) string {
+	var sb strings.Builder
+	marked := false
+	for i, r := range []rune(line) {
+		switch {
+		case i < len(changed) && changed[i]:
+			sb.WriteByte('^')
+			marked = true
+		case r == '\t':
+			sb.WriteByte('\t')
+		default:
+			sb.WriteByte(' ')
+		}
+	}
+	if !marked {
+		return ""
+	}
+	return strings.TrimRight(sb.String(), " \t")
+}
@@ -0,0 +1,60 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	got := "l1\nl2\nl3\nl4\nl5\nl6\nl7\nl8\nl9\nl10\n"
+	want := "l1\nl2\nX3\nl4\nl5\nl6\nl7\nl8\nl9\nl10\nl11\n"
+
+	Equal(t, unifiedDiff(got, want, 1, false), ""+
+		"@@ -2,3 +2,3 @@\n"+
+		" l2\n"+
+		"-l3\n"+
+		"?^\n"+
+		"+X3\n"+
+		"?^\n"+
+		" l4\n"+
+		"@@ -10,1 +10,2 @@\n"+
+		" l10\n"+
+		"+l11\n")
+
+	Equal(t, unifiedDiff("a\nb", "a\nb\n", 3, false), "\\ no newline at end of got\n")
+
+	colored := unifiedDiff("hello world", "hello there", 0, true)
+	True(t, strings.Contains(colored, colorRed+"-hello "+colorReverse+"wo"))
+	True(t, strings.Contains(colored, colorGreen+"+hello "+colorReverse+"the"))
+}
+
+func TestEqualMultilineText(t *testing.T) {
+	type T struct {
+		A    int
+		Body string
+		Raw  []byte
+		Bin  []byte
+	}
+
+	got := T{A: 1, Body: "a\nb\nc\n", Raw: []byte("x\ny\n"), Bin: []byte{0xff, '\n'}}
+	want := T{A: 1, Body: "a\nB\nc\n", Raw: []byte("x\nz\n"), Bin: []byte{0xff, '\n'}}
+
+	atb := &assertTB{TB: t}
+	Equal(atb, got, want, ContextLines(0))
+	atb.fail(t, "T.Body:\n@@ -2,1 +2,1 @@\n-b\n?^\n+B\n?^\nT.Raw:\n@@ -2,1 +2,1 @@\n-y\n?^\n+z\n?^\n")
+
+	atb = &assertTB{TB: t}
+	Equal(atb, "a\nb\n", "a\nc\n")
+	atb.fail(t, "expected equal\ndiff:\n@@ -1,2 +1,2 @@\n a\n-b\n")
+
+	// single-line strings are rendered by cmp
+	atb = &assertTB{TB: t}
+	Equal(atb, "a", "b")
+	atb.fail(t, `"a"`)
+	False(t, strings.Contains(atb.message, "@@"))
+
+	// ignored fields are not rendered
+	atb = &assertTB{TB: t}
+	Equal(atb, got, want, SkipFieldNames("Body", "Raw"))
+	atb.pass(t)
+}
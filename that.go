@@ -0,0 +1,285 @@
+package assert
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+// Value is a fluent wrapper of a checked value, see [That].
+type Value[V any] struct {
+	t   testing.TB
+	got V
+
+	// description is set with As.
+	description string
+
+	// fn is the name of the function creating the wrapper
+	// and pc the location of its call, used to find the
+	// source expression of the value only on failure.
+	fn string
+	pc uintptr
+}
+
+// That returns a fluent wrapper of got, whose methods call
+// the assertion functions of this package and return the wrapper,
+// so checks can be chained:
+//
+//	assert.That(t, user).Equals(want, assert.SkipEmptyFields())
+//	assert.ThatError(t, err).Is(io.EOF)
+//	assert.ThatSlice(t, list).HasLen(3).Contains(x)
+//
+// Failure messages are prefixed with the source expression of got,
+// or with the description set with [Value.As].
+func That[V any](t testing.TB, got V) *Value[V] {
+	return newValue(t, got, "That")
+}
+
+func newValue[V any](t testing.TB, got V, fn string) *Value[V] {
+	// the call of fn is the caller of its caller
+	var pc [1]uintptr
+	runtime.Callers(3, pc[:])
+	return &Value[V]{t: t, got: got, fn: fn, pc: pc[0]}
+}
+
+// As sets the description of the value used in failure messages.
+func (v *Value[V]) As(description string) *Value[V] {
+	v.description = description
+	return v
+}
+
+// Equals checks if the value is equal to want, see [Equal].
+func (v *Value[V]) Equals(want V, opts ...EqualOption) *Value[V] {
+	v.t.Helper()
+	Equal(v.tb(), v.got, want, opts...)
+	return v
+}
+
+// NotEquals checks if the value is not equal to want, see [NotEqual].
+func (v *Value[V]) NotEquals(want V, opts ...EqualOption) *Value[V] {
+	v.t.Helper()
+	NotEqual(v.tb(), v.got, want, opts...)
+	return v
+}
+
+// IsZero checks if the value is zero, see [Zero].
+func (v *Value[V]) IsZero() *Value[V] {
+	v.t.Helper()
+	Zero(v.tb(), v.got)
+	return v
+}
+
+// IsNotZero checks if the value is not zero, see [NotZero].
+func (v *Value[V]) IsNotZero() *Value[V] {
+	v.t.Helper()
+	NotZero(v.tb(), v.got)
+	return v
+}
+
+// IsEmpty checks if the value is empty, see [Empty].
+func (v *Value[V]) IsEmpty() *Value[V] {
+	v.t.Helper()
+	Empty(v.tb(), v.got)
+	return v
+}
+
+// IsNotEmpty checks if the value is not empty, see [NotEmpty].
+func (v *Value[V]) IsNotEmpty() *Value[V] {
+	v.t.Helper()
+	NotEmpty(v.tb(), v.got)
+	return v
+}
+
+// IsNil checks if the value is nil, see [Nil].
+func (v *Value[V]) IsNil() *Value[V] {
+	v.t.Helper()
+	Nil(v.tb(), v.got)
+	return v
+}
+
+// IsNotNil checks if the value is not nil, see [NotNil].
+func (v *Value[V]) IsNotNil() *Value[V] {
+	v.t.Helper()
+	NotNil(v.tb(), v.got)
+	return v
+}
+
+// HasLen checks if the length of the value is want, see [Len].
+func (v *Value[V]) HasLen(want int) *Value[V] {
+	v.t.Helper()
+	Len(v.tb(), v.got, want)
+	return v
+}
+
+// tb returns the testing.TB passed to the assertion functions.
+func (v *Value[V]) tb() testing.TB {
+	return &labelTB{TB: v.t, label: v.label}
+}
+
+// label returns the description of the value or its source expression.
+func (v *Value[V]) label() string {
+	if v.description != "" {
+		return v.description
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{v.pc}).Next()
+	expr, _ := callArg(v.fn, frame.File, frame.Line, 1)
+	return expr
+}
+
+// labelTB prefixes failure messages with the label of the checked value,
+// which is resolved only on failure.
+type labelTB struct {
+	testing.TB
+	label func() string
+}
+
+func (l *labelTB) Fatalf(format string, args ...any) {
+	l.TB.Helper()
+	msg := fmt.Sprintf(format, args...)
+	if label := l.label(); label != "" {
+		msg = label + ": " + msg
+	}
+	l.TB.Fatalf("%s", msg)
+}
+
+// Slice is a fluent wrapper of a checked slice, see [ThatSlice].
+type Slice[E any] struct {
+	*Value[[]E]
+}
+
+// ThatSlice returns a fluent wrapper of the slice got, like [That],
+// whose methods accept only elements of the slice type:
+//
+//	assert.ThatSlice(t, ids).HasLen(3).Contains(id)
+//
+// Strings and maps are checked with [Contains].
+func ThatSlice[E any](t testing.TB, got []E) *Slice[E] {
+	return &Slice[E]{newValue(t, got, "ThatSlice")}
+}
+
+// As sets the description of the slice used in failure messages.
+func (s *Slice[E]) As(description string) *Slice[E] {
+	s.Value.As(description)
+	return s
+}
+
+// Equals checks if the slice is equal to want, see [Equal].
+func (s *Slice[E]) Equals(want []E, opts ...EqualOption) *Slice[E] {
+	s.t.Helper()
+	s.Value.Equals(want, opts...)
+	return s
+}
+
+// NotEquals checks if the slice is not equal to want, see [NotEqual].
+func (s *Slice[E]) NotEquals(want []E, opts ...EqualOption) *Slice[E] {
+	s.t.Helper()
+	s.Value.NotEquals(want, opts...)
+	return s
+}
+
+// HasLen checks if the length of the slice is want, see [Len].
+func (s *Slice[E]) HasLen(want int) *Slice[E] {
+	s.t.Helper()
+	s.Value.HasLen(want)
+	return s
+}
+
+// IsEmpty checks if the slice is empty, see [Empty].
+func (s *Slice[E]) IsEmpty() *Slice[E] {
+	s.t.Helper()
+	s.Value.IsEmpty()
+	return s
+}
+
+// IsNotEmpty checks if the slice is not empty, see [NotEmpty].
+func (s *Slice[E]) IsNotEmpty() *Slice[E] {
+	s.t.Helper()
+	s.Value.IsNotEmpty()
+	return s
+}
+
+// Contains checks if the slice contains elem, see [Contains].
+func (s *Slice[E]) Contains(elem E, opts ...EqualOption) *Slice[E] {
+	s.t.Helper()
+	Contains(s.tb(), s.got, elem, opts...)
+	return s
+}
+
+// Bool is a fluent wrapper of a checked bool, see [ThatBool].
+type Bool struct {
+	*Value[bool]
+}
+
+// ThatBool returns a fluent wrapper of the bool got, like [That]:
+//
+//	assert.ThatBool(t, cache.Has(key)).IsTrue()
+func ThatBool(t testing.TB, got bool) *Bool {
+	return &Bool{newValue(t, got, "ThatBool")}
+}
+
+// As sets the description of the bool used in failure messages.
+func (b *Bool) As(description string) *Bool {
+	b.Value.As(description)
+	return b
+}
+
+// IsTrue checks if the bool is true, see [True].
+func (b *Bool) IsTrue() *Bool {
+	b.t.Helper()
+	True(b.tb(), b.got)
+	return b
+}
+
+// IsFalse checks if the bool is false, see [False].
+func (b *Bool) IsFalse() *Bool {
+	b.t.Helper()
+	False(b.tb(), b.got)
+	return b
+}
+
+// Err is a fluent wrapper of a checked error, see [ThatError].
+type Err struct {
+	*Value[error]
+}
+
+// ThatError returns a fluent wrapper of the error got, like [That]:
+//
+//	assert.ThatError(t, err).Is(io.EOF).Matches("^read")
+func ThatError(t testing.TB, got error) *Err {
+	return &Err{newValue(t, got, "ThatError")}
+}
+
+// As sets the description of the error used in failure messages.
+func (e *Err) As(description string) *Err {
+	e.Value.As(description)
+	return e
+}
+
+// IsError checks if the error is not nil, see [Error].
+func (e *Err) IsError() *Err {
+	e.t.Helper()
+	Error(e.tb(), e.got)
+	return e
+}
+
+// IsNoError checks if the error is nil, see [NoError].
+func (e *Err) IsNoError() *Err {
+	e.t.Helper()
+	NoError(e.tb(), e.got)
+	return e
+}
+
+// Is checks if the error matches target with [errors.Is], see [ErrorContains].
+func (e *Err) Is(target error) *Err {
+	e.t.Helper()
+	ErrorContains(e.tb(), e.got, target)
+	return e
+}
+
+// Matches checks if the error has a message containing
+// or matching the regexp pattern, see [ErrorContains].
+func (e *Err) Matches(pattern string) *Err {
+	e.t.Helper()
+	ErrorContains(e.tb(), e.got, pattern)
+	return e
+}
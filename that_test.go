@@ -0,0 +1,94 @@
+package assert
+
+import (
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestThat(t *testing.T) {
+	type User struct {
+		ID    int
+		Email string
+	}
+
+	user := User{ID: 1, Email: "a@example.com"}
+	list := []int{1, 2, 3}
+	err := fmt.Errorf("read: %w", io.EOF)
+
+	atb := &assertTB{TB: t}
+	That(atb, user).Equals(User{Email: "a@example.com"}, SkipEmptyFields()).IsNotZero()
+	ThatSlice(atb, list).HasLen(3).Contains(2).IsNotEmpty().NotEquals(nil)
+	ThatError(atb, err).IsError().Is(io.EOF).Matches("read")
+	ThatError(atb, nil).IsNoError()
+	ThatBool(atb, list[0] == 1).IsTrue()
+	atb.pass(t)
+
+	atb = &assertTB{TB: t}
+	That(atb, user.ID).Equals(2)
+	atb.fail(t, "user.ID: expected equal")
+
+	atb = &assertTB{TB: t}
+	ThatSlice(atb, list).As("items").Contains(4)
+	atb.fail(t, "items: expected []int{1, 2, 3} to contain 4")
+
+	// the label is resolved on failure from the call of That
+	atb = &assertTB{TB: t}
+	v := That(atb,
+		list[1:])
+	v.HasLen(1)
+	atb.fail(t, "list[1:]: ")
+
+	atb = &assertTB{TB: t}
+	ThatSlice(atb, list).Contains(4)
+	atb.fail(t, "list: expected []int{1, 2, 3} to contain 4")
+
+	atb = &assertTB{TB: t}
+	ThatError(atb, err).Matches("^write")
+	atb.fail(t, "err: ")
+
+	atb = &assertTB{TB: t}
+	ThatError(atb, err).Is(io.ErrClosedPipe)
+	atb.fail(t, "err: unexpected error")
+
+	atb = &assertTB{TB: t}
+	ThatBool(atb, list[0] == 2).As("first").IsTrue()
+	atb.fail(t, "first: expected true, got false")
+}
+
+func TestContains(t *testing.T) {
+	type Item struct {
+		ID   int
+		Name string
+	}
+
+	tests := []struct {
+		name string
+		got  any
+		elem any
+		opts []EqualOption
+		fail string
+	}{
+		{"string", "hello world", "o w", nil, ""},
+		{"string missing", "hello", "x", nil, `expected "hello" to contain "x"`},
+		{"slice", []Item{{1, "a"}, {2, "b"}}, Item{ID: 2}, []EqualOption{SkipEmptyFields()}, ""},
+		{"slice missing", []Item{{1, "a"}}, Item{ID: 2}, nil, `to contain assert.Item{ID: 2, Name: ""}`},
+		{"array", [2]string{"a", "b"}, "b", nil, ""},
+		{"map", map[string]int{"a": 1}, "a", nil, ""},
+		{"map missing", map[string]int{"a": 1}, "b", nil, `to contain "b"`},
+		{"interface", []any{1, "a"}, "a", nil, ""},
+		{"nil", []error{nil}, nil, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atb := &assertTB{TB: t}
+			Contains(atb, tt.got, tt.elem, tt.opts...)
+			atb.check(t, tt.fail)
+		})
+	}
+
+	Panic(t, func() { Contains(t, []int{1}, "1") })
+	Panic(t, func() { Contains(t, "1", 1) })
+	Panic(t, func() { Contains(t, 1, 1) })
+}
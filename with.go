@@ -0,0 +1,62 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// With returns a testing.TB that prefixes messages reported with
+// Fatal, Error and Log methods with the annotation formatted
+// according to format and args. It is useful to tell which item
+// failed when assertions are made in a loop:
+//
+//	for _, user := range users {
+//		t := assert.With(t, "user %d", user.ID)
+//		assert.NotZero(t, user.Email)
+//	}
+//
+// Annotations can be nested, the outermost one is printed first.
+func With(t testing.TB, format string, args ...any) testing.TB {
+	return &withTB{TB: t, annotation: fmt.Sprintf(format, args...)}
+}
+
+type withTB struct {
+	testing.TB
+	annotation string
+}
+
+func (w *withTB) Fatal(args ...any) {
+	w.TB.Helper()
+	w.TB.Fatal(w.annotate(fmt.Sprintln(args...)))
+}
+
+func (w *withTB) Fatalf(format string, args ...any) {
+	w.TB.Helper()
+	w.TB.Fatalf("%s", w.annotate(fmt.Sprintf(format, args...)))
+}
+
+func (w *withTB) Error(args ...any) {
+	w.TB.Helper()
+	w.TB.Error(w.annotate(fmt.Sprintln(args...)))
+}
+
+func (w *withTB) Errorf(format string, args ...any) {
+	w.TB.Helper()
+	w.TB.Errorf("%s", w.annotate(fmt.Sprintf(format, args...)))
+}
+
+func (w *withTB) Log(args ...any) {
+	w.TB.Helper()
+	w.TB.Log(w.annotate(fmt.Sprintln(args...)))
+}
+
+func (w *withTB) Logf(format string, args ...any) {
+	w.TB.Helper()
+	w.TB.Logf("%s", w.annotate(fmt.Sprintf(format, args...)))
+}
+
+// annotate prefixes msg with the annotation.
+func (w *withTB) annotate(msg string) string {
+	return w.annotation + ": " + strings.TrimSuffix(msg, "\n")
+}
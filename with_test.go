@@ -0,0 +1,33 @@
+package assert
+
+import (
+	"testing"
+)
+
+func TestWith(t *testing.T) {
+	users := []struct {
+		ID    int
+		Email string
+	}{
+		{ID: 1, Email: "a@example.com"},
+		{ID: 2},
+	}
+
+	atb := &assertTB{TB: t}
+	for _, user := range users {
+		NotZero(With(atb, "user %d", user.ID), user.Email)
+	}
+	atb.fail(t, `user 2: user.Email: expected not zero, got ""`)
+
+	atb = &assertTB{TB: t}
+	Equal(With(With(atb, "outer"), "inner %s", "%d"), 1, 2)
+	atb.fail(t, "outer: inner %d: expected equal")
+
+	etb := &errorTB{TB: t}
+	True(Soft(With(etb, "soft")), false)
+	Equal(t, etb.errors, []string{"soft: expected true, got false"})
+
+	ltb := &logTB{assertTB: assertTB{TB: t}}
+	With(ltb, "a").Logf("b %d", 1)
+	Equal(t, ltb.logs, []string{"a: b 1"})
+}
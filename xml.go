@@ -0,0 +1,311 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// XMLEq checks if got and want are semantically equal XML documents.
+//
+// Documents are compared ignoring attribute order, whitespace around
+// text, comments, processing instructions and namespace prefixes,
+// so elements are equal if their namespace URIs and local names are equal.
+//
+// [SkipFieldNames] can be used to ignore elements and attributes by path,
+// e.g. "feed/entry/id" or "feed/entry/@lang". A path consists of
+// slash-delimited local names, where "*" matches any name. Paths
+// starting with "//" match at any depth, e.g. "//@id".
+//
+// The failure message points to the XPath-like location
+// of the first difference.
+func XMLEq[D ~string | ~[]byte](t testing.TB, got D, want D, opts ...EqualOption) bool {
+	t.Helper()
+	return failed(t, 1, CheckXMLEq(got, want, opts...))
+}
+
+// CheckXMLEq returns a [*Failure] if got and want are not
+// semantically equal XML documents, see [XMLEq].
+func CheckXMLEq[D ~string | ~[]byte](got D, want D, opts ...EqualOption) error {
+	gotn, err := decodeXML([]byte(got))
+	if err != nil {
+		return &Failure{Message: fmt.Sprintf("invalid got XML: %v", err), Got: got}
+	}
+
+	wantn, err := decodeXML([]byte(want))
+	if err != nil {
+		return &Failure{Message: fmt.Sprintf("invalid want XML: %v", err), Want: want}
+	}
+
+	xc := newXMLComparer(newEqualer(opts...))
+	if diff := xc.compare("", nil, gotn, wantn); diff != "" {
+		return &Failure{Message: "expected equal XML", Got: got, Want: want, Diff: diff}
+	}
+	return nil
+}
+
+// xmlNode is an element or a text node of an XML document.
+type xmlNode struct {
+	name     xml.Name // element name, empty for text nodes
+	attrs    []xml.Attr
+	children []*xmlNode
+	text     string
+}
+
+func (n *xmlNode) isText() bool {
+	return n.name.Local == ""
+}
+
+func (n *xmlNode) String() string {
+	if n.isText() {
+		return "text " + strconv.Quote(n.text)
+	}
+	if n.name.Space != "" {
+		return fmt.Sprintf("element <%s> in namespace %q", n.name.Local, n.name.Space)
+	}
+	return fmt.Sprintf("element <%s>", n.name.Local)
+}
+
+// decodeXML decodes the root element of an XML document.
+func decodeXML(data []byte) (*xmlNode, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+
+	var root *xmlNode
+	var stack []*xmlNode
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			n := &xmlNode{name: tok.Name}
+			for _, attr := range tok.Attr {
+				// namespace declarations are resolved by the decoder
+				if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+					continue
+				}
+				n.attrs = append(n.attrs, attr)
+			}
+			sort.Slice(n.attrs, func(i, j int) bool {
+				return xmlAttrName(n.attrs[i].Name) < xmlAttrName(n.attrs[j].Name)
+			})
+
+			if len(stack) == 0 {
+				if root != nil {
+					return nil, fmt.Errorf("multiple root elements")
+				}
+				root = n
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			}
+			stack = append(stack, n)
+
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+
+		case xml.CharData:
+			text := strings.TrimSpace(string(tok))
+			if text == "" {
+				continue
+			}
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("text outside of the root element")
+			}
+			parent := stack[len(stack)-1]
+			if last := len(parent.children) - 1; last >= 0 && parent.children[last].isText() {
+				// merge text split by comments or CDATA sections
+				parent.children[last].text += text
+				continue
+			}
+			parent.children = append(parent.children, &xmlNode{text: text})
+		}
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("missing root element")
+	}
+	return root, nil
+}
+
+func xmlAttrName(name xml.Name) string {
+	if name.Space != "" {
+		return name.Space + ":" + name.Local
+	}
+	return name.Local
+}
+
+type xmlComparer struct {
+	// skip are the ignored paths, each as a list of local names
+	// where attributes are prefixed with "@".
+	skip [][]string
+
+	// anywhere reports whether the skip pattern at the same
+	// index matches at any depth.
+	anywhere []bool
+}
+
+func newXMLComparer(eq *equaler) *xmlComparer {
+	xc := &xmlComparer{}
+	for _, name := range eq.skipFieldNames {
+		anywhere := strings.HasPrefix(name, "//")
+		name = strings.Trim(name, "/")
+		xc.skip = append(xc.skip, strings.Split(name, "/"))
+		xc.anywhere = append(xc.anywhere, anywhere)
+	}
+	return xc
+}
+
+// skipped reports whether the node at names path is ignored.
+func (xc *xmlComparer) skipped(names []string) bool {
+	for i, pattern := range xc.skip {
+		p := names
+		if xc.anywhere[i] && len(p) > len(pattern) {
+			p = p[len(p)-len(pattern):]
+		}
+		if len(p) != len(pattern) {
+			continue
+		}
+
+		ok := true
+		for j := range pattern {
+			if pattern[j] != p[j] && !(pattern[j] == "*" && !strings.HasPrefix(p[j], "@")) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// compare returns the first difference between got and want,
+// where path is the location of got and want and names
+// is the list of local names of their parents.
+func (xc *xmlComparer) compare(path string, names []string, got, want *xmlNode) string {
+	if got.isText() != want.isText() || got.name != want.name {
+		return fmt.Sprintf("at %s: got %s, want %s", xmlNodePath(path, got), got, want)
+	}
+
+	if got.isText() {
+		if got.text != want.text {
+			return fmt.Sprintf("at %s/text(): got %q, want %q", path, got.text, want.text)
+		}
+		return ""
+	}
+
+	if path == "" {
+		path = "/" + got.name.Local
+	}
+	names = append(names[:len(names):len(names)], got.name.Local)
+
+	if diff := xc.compareAttrs(path, names, got.attrs, want.attrs); diff != "" {
+		return diff
+	}
+
+	gotc := xc.children(names, got.children)
+	wantc := xc.children(names, want.children)
+	for i := 0; i < max(len(gotc), len(wantc)); i++ {
+		switch {
+		case i >= len(gotc):
+			return fmt.Sprintf("at %s: missing %s", path, wantc[i].node)
+		case i >= len(wantc):
+			return fmt.Sprintf("at %s: unexpected %s", gotc[i].path(path), gotc[i].node)
+		}
+
+		if diff := xc.compare(gotc[i].path(path), names, gotc[i].node, wantc[i].node); diff != "" {
+			return diff
+		}
+	}
+	return ""
+}
+
+func (xc *xmlComparer) compareAttrs(path string, names []string, got, want []xml.Attr) string {
+	gota := map[xml.Name]string{}
+	for _, attr := range got {
+		gota[attr.Name] = attr.Value
+	}
+	wanta := map[xml.Name]string{}
+	for _, attr := range want {
+		wanta[attr.Name] = attr.Value
+	}
+
+	// both lists are sorted, so report differences in a stable order
+	for _, attr := range append(got[:len(got):len(got)], want...) {
+		if xc.skipped(append(names[:len(names):len(names)], "@"+attr.Name.Local)) {
+			continue
+		}
+
+		attrPath := path + "/@" + attr.Name.Local
+		g, gok := gota[attr.Name]
+		w, wok := wanta[attr.Name]
+		switch {
+		case !gok:
+			return fmt.Sprintf("at %s: got <missing>, want %q", attrPath, w)
+		case !wok:
+			return fmt.Sprintf("at %s: got %q, want <missing>", attrPath, g)
+		case g != w:
+			return fmt.Sprintf("at %s: got %q, want %q", attrPath, g, w)
+		}
+	}
+	return ""
+}
+
+// xmlChild is a child node along with its position
+// among siblings of the same name.
+type xmlChild struct {
+	node  *xmlNode
+	index int // 1-based index, 0 if the node has no siblings of the same name
+}
+
+func (c xmlChild) path(parent string) string {
+	if c.node.isText() {
+		return parent
+	}
+	if c.index > 0 {
+		return fmt.Sprintf("%s/%s[%d]", parent, c.node.name.Local, c.index)
+	}
+	return parent + "/" + c.node.name.Local
+}
+
+// children returns nodes that are not ignored.
+func (xc *xmlComparer) children(names []string, nodes []*xmlNode) []xmlChild {
+	count := map[xml.Name]int{}
+	var out []xmlChild
+	for _, n := range nodes {
+		if !n.isText() && xc.skipped(append(names[:len(names):len(names)], n.name.Local)) {
+			continue
+		}
+		out = append(out, xmlChild{node: n})
+		count[n.name]++
+	}
+
+	seen := map[xml.Name]int{}
+	for i, c := range out {
+		if c.node.isText() || count[c.node.name] == 1 {
+			continue
+		}
+		seen[c.node.name]++
+		out[i].index = seen[c.node.name]
+	}
+	return out
+}
+
+func xmlNodePath(path string, n *xmlNode) string {
+	if path == "" {
+		return "/" + n.name.Local
+	}
+	return path
+}
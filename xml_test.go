@@ -0,0 +1,93 @@
+package assert
+
+import (
+	"testing"
+)
+
+func TestXMLEq(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+		opts []EqualOption
+		fail string
+	}{
+		{
+			name: "attribute order and whitespace",
+			got:  `<?xml version="1.0"?><feed a="1" b="2"><title> News </title></feed>`,
+			want: "<feed b=\"2\" a=\"1\">\n  <title>News</title>\n</feed>",
+		},
+		{
+			name: "namespace prefixes",
+			got:  `<a:feed xmlns:a="urn:atom"><a:entry a:id="1"/></a:feed>`,
+			want: `<feed xmlns="urn:atom" xmlns:x="urn:atom"><entry x:id="1"/></feed>`,
+		},
+		{
+			name: "comments",
+			got:  `<feed><!-- generated --><title>News</title></feed>`,
+			want: `<feed><title>News</title></feed>`,
+		},
+		{
+			name: "different text",
+			got:  `<feed><entry>a</entry><entry><title>b</title></entry></feed>`,
+			want: `<feed><entry>a</entry><entry><title>c</title></entry></feed>`,
+			fail: `at /feed/entry[2]/title/text(): got "b", want "c"`,
+		},
+		{
+			name: "different attribute",
+			got:  `<feed><entry lang="en"/></feed>`,
+			want: `<feed><entry lang="pl"/></feed>`,
+			fail: `at /feed/entry/@lang: got "en", want "pl"`,
+		},
+		{
+			name: "missing attribute",
+			got:  `<feed><entry/></feed>`,
+			want: `<feed><entry lang="pl"/></feed>`,
+			fail: `at /feed/entry/@lang: got <missing>, want "pl"`,
+		},
+		{
+			name: "different namespace",
+			got:  `<feed xmlns="urn:a"/>`,
+			want: `<feed xmlns="urn:b"/>`,
+			fail: `at /feed: got element <feed> in namespace "urn:a", want element <feed> in namespace "urn:b"`,
+		},
+		{
+			name: "missing element",
+			got:  `<feed><entry/></feed>`,
+			want: `<feed><entry/><entry/></feed>`,
+			fail: "at /feed: missing element <entry>",
+		},
+		{
+			name: "unexpected element",
+			got:  `<feed><entry/><id/></feed>`,
+			want: `<feed><entry/></feed>`,
+			fail: "at /feed/id: unexpected element <id>",
+		},
+		{
+			name: "skip paths",
+			got:  `<feed><id>1</id><entry lang="en"><id>2</id></entry></feed>`,
+			want: `<feed><entry><id>3</id></entry></feed>`,
+			opts: []EqualOption{SkipFieldNames("/feed/id", "feed/*/@lang", "//entry/id")},
+		},
+		{
+			name: "invalid got",
+			got:  `<feed>`,
+			want: `<feed/>`,
+			fail: "invalid got XML",
+		},
+		{
+			name: "invalid want",
+			got:  `<feed/>`,
+			want: `<feed/><feed/>`,
+			fail: "invalid want XML: multiple root elements",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atb := &assertTB{TB: t}
+			XMLEq(atb, tt.got, tt.want, tt.opts...)
+			atb.check(t, tt.fail)
+		})
+	}
+}